@@ -9,6 +9,8 @@ import (
 
 	"github.com/museslabs/kyma/docs"
 	"github.com/museslabs/kyma/internal/config"
+	"github.com/museslabs/kyma/internal/deck"
+	"github.com/museslabs/kyma/internal/img"
 	"github.com/museslabs/kyma/internal/logger"
 	"github.com/museslabs/kyma/internal/tui"
 )
@@ -29,7 +31,15 @@ var docsCmd = &cobra.Command{
 			return err
 		}
 
-		data, err := docs.FS.ReadFile("presentation.md")
+		if err := applyReducedMotion(cmd); err != nil {
+			return err
+		}
+		if err := applyGraphics(); err != nil {
+			return err
+		}
+		applyImageCache()
+
+		src, err := deck.LoadFS(docs.FS, "presentation.md")
 		if err != nil {
 			slog.Error(
 				"Failed to read presentation file",
@@ -41,7 +51,7 @@ var docsCmd = &cobra.Command{
 			return err
 		}
 
-		root, err := parseSlides(string(data))
+		root, settings, err := parseSlides(src)
 		if err != nil {
 			slog.Error("Failed to parse slides", "error", err, "filename", "presentation.md")
 			return err
@@ -49,8 +59,9 @@ var docsCmd = &cobra.Command{
 
 		slog.Info("Successfully parsed presentation")
 
+		img.SetAsync(true)
 		p := tea.NewProgram(
-			tui.New(root, "presentation.md"),
+			tui.New(root, settings, "", tui.NewAnnotations()),
 			tea.WithAltScreen(),
 			tea.WithMouseAllMotion(),
 		)
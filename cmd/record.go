@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/museslabs/kyma/internal/config"
+	"github.com/museslabs/kyma/internal/deck"
+	"github.com/museslabs/kyma/internal/img"
+	"github.com/museslabs/kyma/internal/logger"
+	"github.com/museslabs/kyma/internal/record"
+	"github.com/museslabs/kyma/internal/tui"
+)
+
+var (
+	recordOutput string
+	recordWidth  int
+	recordHeight int
+	recordHold   time.Duration
+)
+
+func init() {
+	recordCmd.Flags().
+		StringVarP(&recordOutput, "output", "o", "", "File to write the recording to, ending in .cast or .gif")
+	recordCmd.Flags().IntVar(&recordWidth, "width", 100, "Width of the recording in cells")
+	recordCmd.Flags().IntVar(&recordHeight, "height", 30, "Height of the recording in cells")
+	recordCmd.Flags().DurationVar(&recordHold, "hold", 3*time.Second, "How long each slide is shown for")
+	recordCmd.Flags().
+		BoolVar(&annotated, "annotations", false, "Draw the annotations saved next to the presentation")
+	_ = recordCmd.MarkFlagRequired("output")
+}
+
+var recordCmd = &cobra.Command{
+	Use:   "record <filename|directory> -o <output.cast|output.gif>",
+	Short: "Record a presentation to an asciinema cast or an animated GIF",
+	Long: "Run through a presentation without a terminal, showing every slide for a while and " +
+		"playing the transitions between them, and write it as an asciinema cast or an animated GIF.",
+	Args: rootCmd.Args,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		write, err := recordWriter(recordOutput)
+		if err != nil {
+			return err
+		}
+		if recordWidth <= 0 || recordHeight <= 0 {
+			return fmt.Errorf("expected a positive size, got %dx%d", recordWidth, recordHeight)
+		}
+
+		if err := logger.Load(logPath); err != nil {
+			return fmt.Errorf("failed to initialize slog: %w", err)
+		}
+
+		slog.Info("Starting Kyma recording")
+
+		if err := config.Load(configPath); err != nil {
+			slog.Error("Failed to load config", "error", err, "config_path", configPath)
+			return err
+		}
+
+		if err := applyReducedMotion(cmd); err != nil {
+			return err
+		}
+		applyImageCache()
+
+		filename := args[0]
+		src, err := deck.Load(filename)
+		if err != nil {
+			slog.Error("Failed to read presentation file", "error", err, "filename", filename)
+			return err
+		}
+
+		root, settings, err := parseSlides(src)
+		if err != nil {
+			slog.Error("Failed to parse slides", "error", err, "filename", filename)
+			return err
+		}
+
+		// There is no terminal to draw images in pixels in
+		img.SetGraphics(img.GraphicsSymbols)
+
+		frames := tui.Record(root, settings, loadAnnotations(filename), recordWidth, recordHeight, recordHold)
+		slog.Info("Recorded presentation", "frames", len(frames))
+
+		f, err := os.Create(recordOutput)
+		if err != nil {
+			return err
+		}
+		if err := write(f, frames, recordWidth, recordHeight); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write %s: %w", recordOutput, err)
+		}
+		return f.Close()
+	},
+}
+
+// recordWriter returns the function writing a recording in the format output
+// ends in.
+func recordWriter(output string) (func(io.Writer, []record.Frame, int, int) error, error) {
+	switch filepath.Ext(output) {
+	case ".cast":
+		return record.WriteCast, nil
+	case ".gif":
+		return record.WriteGIF, nil
+	default:
+		return nil, fmt.Errorf("expected an output ending in .cast or .gif, got: %v", output)
+	}
+}
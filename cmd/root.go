@@ -5,14 +5,14 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
-	"strings"
-	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
 
 	"github.com/museslabs/kyma/internal/config"
+	"github.com/museslabs/kyma/internal/deck"
+	"github.com/museslabs/kyma/internal/img"
 	"github.com/museslabs/kyma/internal/logger"
 	"github.com/museslabs/kyma/internal/tui"
 	"github.com/museslabs/kyma/internal/tui/transitions"
@@ -23,6 +23,11 @@ var (
 	configPath string
 	logPath    string
 	notes      bool
+	audience   string
+	annotated  bool
+
+	reducedMotion string
+	graphics      string
 )
 
 func init() {
@@ -32,19 +37,34 @@ func init() {
 	rootCmd.Flags().
 		StringVarP(&logPath, "log", "l", "", "Path to log file (default: ~/.config/kyma/logs/<timestamp>.kyma.log)")
 	rootCmd.Flags().BoolVarP(&notes, "notes", "n", false, "Run in speaker notes mode")
+	rootCmd.Flags().
+		StringVarP(&audience, "audience", "a", "", "Only present slides meant for this audience")
+	rootCmd.Flags().
+		BoolVar(&annotated, "annotations", false, "Restore the annotations saved next to the presentation")
+	rootCmd.PersistentFlags().
+		StringVar(&reducedMotion, "reduced-motion", "", "Tone transitions down: auto, off, fade or none")
+	rootCmd.PersistentFlags().Lookup("reduced-motion").NoOptDefVal = string(transitions.ReducedMotionFade)
+	rootCmd.PersistentFlags().
+		StringVar(&graphics, "graphics", "", "Draw images with: auto, kitty, sixel, iterm2 or symbols")
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(docsCmd)
+	rootCmd.AddCommand(transitionsCmd)
+	rootCmd.AddCommand(recordCmd)
 }
 
 var rootCmd = &cobra.Command{
-	Use: "kyma <filename>",
+	Use: "kyma <filename|directory>",
 	Args: func(cmd *cobra.Command, args []string) error {
 		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
 			return err
 		}
 
+		if info, err := os.Stat(args[0]); err == nil && info.IsDir() {
+			return nil
+		}
+
 		if filepath.Ext(args[0]) != ".md" {
-			return fmt.Errorf("expected markdown file got: %v", args[0])
+			return fmt.Errorf("expected markdown file or directory got: %v", args[0])
 		}
 		return nil
 	},
@@ -70,16 +90,24 @@ var rootCmd = &cobra.Command{
 			return err
 		}
 
+		if err := applyReducedMotion(cmd); err != nil {
+			return err
+		}
+		if err := applyGraphics(); err != nil {
+			return err
+		}
+		applyImageCache()
+
 		filename := args[0]
 		slog.Info("Loading presentation", "filename", filename)
 
-		data, err := os.ReadFile(filename)
+		src, err := deck.Load(filename)
 		if err != nil {
 			slog.Error("Failed to read presentation file", "error", err, "filename", filename)
 			return err
 		}
 
-		root, err := parseSlides(string(data))
+		root, settings, err := parseSlides(src)
 		if err != nil {
 			slog.Error("Failed to parse slides", "error", err, "filename", filename)
 			return err
@@ -88,7 +116,7 @@ var rootCmd = &cobra.Command{
 		slog.Info("Successfully parsed presentation")
 
 		if notes {
-			speakerModel := tui.NewSpeakerNotes(root)
+			speakerModel := tui.NewSpeakerNotes(root, settings)
 			p := tea.NewProgram(speakerModel, tea.WithAltScreen())
 			if _, err := p.Run(); err != nil {
 				return err
@@ -96,44 +124,21 @@ var rootCmd = &cobra.Command{
 			return nil
 		}
 
-		p := tea.NewProgram(tui.New(root, filename), tea.WithAltScreen(), tea.WithMouseAllMotion())
+		// Slides show while their images are drawn, redrawn once they are
+		img.SetAsync(true)
+		p := tea.NewProgram(tui.New(root, settings, filename, loadAnnotations(filename)), tea.WithAltScreen(), tea.WithMouseAllMotion())
 
 		if !static {
 			slog.Info("Starting file watcher for live reload")
 			watcher, err := fsnotify.NewWatcher()
 			if err != nil {
 				slog.Error("Failed to create file watcher", "error", err)
-				p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
+				p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err)})
 				return nil
 			}
 			defer watcher.Close()
 
-			absPath, err := filepath.Abs(filename)
-			if err != nil {
-				p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-				return nil
-			}
-
-			if err := watcher.Add(filepath.Dir(absPath)); err != nil {
-				p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-			}
-
-			if configPath != "" {
-				configDir := filepath.Dir(configPath)
-				if err := watcher.Add(configDir); err != nil {
-					p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-				}
-			} else {
-				home, err := os.UserHomeDir()
-				if err == nil {
-					configDir := filepath.Join(home, ".config")
-					if err := watcher.Add(configDir); err != nil {
-						p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-					}
-				}
-			}
-
-			go watchFileChanges(watcher, p, filename, absPath, configPath)
+			go newDeckWatcher(watcher, p, filename, configPath, src, root).run()
 		}
 
 		slog.Info("Starting TUI program")
@@ -147,85 +152,6 @@ var rootCmd = &cobra.Command{
 	},
 }
 
-func watchFileChanges(
-	watcher *fsnotify.Watcher,
-	p *tea.Program,
-	filename, absPath string,
-	configPath string,
-) {
-	var debounceTimer *time.Timer
-
-	for {
-		select {
-		case event, ok := <-watcher.Events:
-			if !ok {
-				return
-			}
-
-			if event.Name == absPath || event.Name == filename ||
-				strings.HasSuffix(event.Name, "~") ||
-				strings.HasPrefix(event.Name, absPath+".") ||
-				event.Name == configPath {
-
-				if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) {
-					if debounceTimer != nil {
-						debounceTimer.Stop()
-					}
-					debounceTimer = time.AfterFunc(100*time.Millisecond, func() {
-						slog.Info("File changed, reloading presentation", "file", event.Name)
-
-						data, err := os.ReadFile(filename)
-						if err != nil {
-							slog.Error(
-								"Failed to read file during reload",
-								"error",
-								err,
-								"filename",
-								filename,
-							)
-							p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-							return
-						}
-
-						if err := config.Load(configPath); err != nil {
-							slog.Error(
-								"Failed to reload config",
-								"error",
-								err,
-								"config_path",
-								configPath,
-							)
-							p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-							return
-						}
-
-						newRoot, err := parseSlides(string(data))
-						if err != nil {
-							slog.Error(
-								"Failed to parse slides during reload",
-								"error",
-								err,
-								"filename",
-								filename,
-							)
-							p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-							return
-						}
-
-						slog.Info("Successfully reloaded presentation")
-						p.Send(tui.UpdateSlidesMsg{NewRoot: newRoot})
-					})
-				}
-			}
-		case err, ok := <-watcher.Errors:
-			if !ok {
-				return
-			}
-			p.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err, "none")})
-		}
-	}
-}
-
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		slog.Error("Application failed", "error", err)
@@ -234,60 +160,205 @@ func Execute() {
 	}
 }
 
-func parseSlides(data string) (*tui.Slide, error) {
-	slides := strings.Split(string(data), "----\n")
+// slideTemplateData is what template actions in slide bodies are executed
+// with.
+type slideTemplateData struct {
+	SlideNumber int
+	Total       int
+	Deck        config.DeckConfig
+	Vars        map[string]any
+}
+
+// parseSlides parses the slides of src, along with the deck settings they
+// are shown with.
+func parseSlides(src *deck.Source) (*tui.Slide, tui.Deck, error) {
+	if err := config.LoadDeck(src.Headers...); err != nil {
+		return nil, tui.Deck{}, fmt.Errorf("invalid deck settings: %w", err)
+	}
+	settings := tui.Deck{
+		Config:         config.Deck,
+		JumpTransition: config.JumpTransition(),
+	}
 
-	rootSlide, properties := parseSlide(slides[0])
-	p, err := config.NewProperties(properties)
+	slides := src.Slides()
 
-	if err != nil {
-		return nil, err
+	props := make([]config.Properties, len(slides))
+	for i, raw := range slides {
+		p, err := config.NewProperties(raw.Properties)
+		if err != nil {
+			return nil, tui.Deck{}, fmt.Errorf("%s: %w", raw.Position, err)
+		}
+		props[i] = p
 	}
+	sections := assignSections(props)
 
-	root, err := tui.NewSlide(rootSlide, p)
-	if err != nil {
-		return nil, err
+	// Slides not shown to the audience are left out of the numbering
+	total := 0
+	for _, p := range props {
+		if p.ShownTo(audience) {
+			total++
+		}
 	}
 
-	curr := root
-	for _, slide := range slides[1:] {
-		slide, properties := parseSlide(slide)
-		p, err := config.NewProperties(properties)
+	var root, curr *tui.Slide
+
+	number := 0
+	for i, raw := range slides {
+		p := props[i]
+
+		shown := p.ShownTo(audience)
+		if shown {
+			number++
+		}
+
+		body, err := deck.Expand(raw.Body, slideTemplateData{
+			SlideNumber: number,
+			Total:       total,
+			Deck:        settings.Config,
+			Vars:        settings.Config.Vars,
+		})
 		if err != nil {
-			return nil, err
+			return nil, tui.Deck{}, fmt.Errorf("%s: %w", raw.Position, err)
 		}
 
-		curr.Next, err = tui.NewSlide(slide, p)
+		if p.Type == config.SlideTypeTOC {
+			body = deck.TOC(body, sections, p.Section)
+		}
+
+		slide, err := tui.NewSlide(body, raw.Dir, p)
 		if err != nil {
-			return nil, err
+			return nil, tui.Deck{}, fmt.Errorf("%s: %w", raw.Position, err)
+		}
+		slide.Skipped = !shown
+
+		if root == nil {
+			root = slide
+		} else {
+			curr.Next = slide
+			slide.Prev = curr
 		}
-		curr.Next.Prev = curr
-		curr = curr.Next
+		curr = slide
 	}
 
-	return root, nil
+	return root, settings, nil
 }
 
-func parseSlide(s string) (slide, properties string) {
-	slide = s
+// assignSections sets the section of every slide without one, and returns the
+// sections of the deck presented to the audience, in order. Slides inherit
+// the section of the slide before them, except table of contents slides,
+// which belong to the section they introduce: the next one in the deck.
+func assignSections(props []config.Properties) []string {
+	for i := range props {
+		if props[i].Type != config.SlideTypeTOC || props[i].Section != "" {
+			continue
+		}
+		for _, next := range props[i+1:] {
+			if next.Section != "" {
+				props[i].Section = next.Section
+				break
+			}
+		}
+	}
+
+	names := make([]string, 0, len(props))
+	section := ""
+	for i := range props {
+		if props[i].Section == "" {
+			props[i].Section = section
+		}
+		section = props[i].Section
+
+		// Sections with nothing to present are left out of agendas
+		if props[i].ShownTo(audience) {
+			names = append(names, section)
+		}
+	}
+
+	return deck.Sections(names)
+}
 
-	if strings.HasPrefix(strings.TrimSpace(s), "---\n") {
-		parts := strings.Split(s, "---\n")
-		properties = parts[1]
-		slide = parts[2]
+// applyReducedMotion sets how transitions are toned down, the flag taking
+// precedence over the environment and the config.
+func applyReducedMotion(cmd *cobra.Command) error {
+	r, err := config.ReducedMotion()
+	if cmd.Flags().Changed("reduced-motion") {
+		r, err = transitions.ParseReducedMotion(reducedMotion)
+	}
+	if err != nil {
+		return err
 	}
 
-	return slide, properties
+	slog.Info("Reduced motion", "mode", r)
+	transitions.SetReducedMotion(r)
+	return nil
+}
+
+// applyGraphics sets the protocol images are drawn with, probed for unless
+// the flag sets one.
+func applyGraphics() error {
+	g, err := img.ParseGraphics(graphics)
+	if err != nil {
+		return err
+	}
+
+	img.SetGraphics(g)
+	slog.Info("Graphics", "requested", g, "protocol", img.CurrentGraphics())
+	return nil
+}
+
+// applyImageCache sizes the cache images are drawn into, and keeps it on disk
+// when the config asks for it. Images are drawn all the same without it.
+func applyImageCache() {
+	c := config.GlobalConfig.ImageCache
+	if c.Memory > 0 {
+		img.SetCacheBudget(c.Memory << 20)
+	}
+	if !c.Disk {
+		return
+	}
+
+	diskBudget := int64(img.DefaultDiskCacheBudget)
+	if c.DiskSize > 0 {
+		diskBudget = int64(c.DiskSize) << 20
+	}
+
+	dir, err := os.UserCacheDir()
+	if err == nil {
+		dir = filepath.Join(dir, "kyma", "images")
+		err = img.SetCacheDir(dir, diskBudget)
+	}
+	if err != nil {
+		slog.Warn("Failed to set up the image disk cache", "error", err)
+		return
+	}
+	slog.Info("Image disk cache", "dir", dir)
+}
+
+// loadAnnotations returns the annotations saved next to filename when the
+// flag asks for them, and none otherwise.
+func loadAnnotations(filename string) tui.Annotations {
+	if !annotated {
+		return tui.NewAnnotations()
+	}
+
+	path := tui.AnnotationsFile(filename)
+	annotations, err := tui.LoadAnnotations(path)
+	if err != nil {
+		slog.Warn("Failed to load annotations", "error", err, "path", path)
+		return tui.NewAnnotations()
+	}
+	slog.Info("Restored annotations", "path", path)
+	return annotations
 }
 
-func createErrorSlide(err error, transition string) *tui.Slide {
+func createErrorSlide(err error) *tui.Slide {
 	return &tui.Slide{
 		Data: fmt.Sprintf(
 			"# Error while updating\n\n%s\n\nIf you believe this is our fault, please open up an issue on GitHub",
 			err.Error(),
 		),
 		Properties: config.Properties{
-			Transition: transitions.Get(transition, transitions.Fps),
+			Transition: transitions.None(),
 		},
 	}
 }
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/museslabs/kyma/internal/config"
+	"github.com/museslabs/kyma/internal/deck"
+	"github.com/museslabs/kyma/internal/logger"
+	"github.com/museslabs/kyma/internal/tui"
+	"github.com/museslabs/kyma/internal/tui/transitions"
+)
+
+var listTransitions bool
+
+func init() {
+	transitionsCmd.Flags().
+		BoolVar(&listTransitions, "list", false, "Print the names of the transitions instead of previewing them")
+}
+
+var transitionsCmd = &cobra.Command{
+	Use:   "transitions [name...]",
+	Short: "Preview the available transitions",
+	Long: "Preview the available transitions as a presentation, each slide playing one of them " +
+		"when moved to, and backwards when moved back from. Names restrict the preview to " +
+		"those transitions.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := transitions.Names()
+		if len(args) > 0 {
+			for _, name := range args {
+				if _, err := transitions.Get(name, transitions.Params{}); err != nil {
+					return err
+				}
+			}
+			names = args
+		}
+
+		if listTransitions {
+			for _, name := range names {
+				fmt.Println(name)
+			}
+			return nil
+		}
+
+		if err := logger.Load(logPath); err != nil {
+			return fmt.Errorf("failed to initialize slog: %w", err)
+		}
+
+		slog.Info("Starting Kyma transitions preview")
+
+		if err := config.Load(configPath); err != nil {
+			slog.Error("Failed to load config", "error", err, "config_path", configPath)
+			return err
+		}
+
+		if err := applyReducedMotion(cmd); err != nil {
+			return err
+		}
+		if err := applyGraphics(); err != nil {
+			return err
+		}
+
+		root, settings, err := parseSlides(&deck.Source{Text: transitionsPreview(names)})
+		if err != nil {
+			slog.Error("Failed to parse slides", "error", err)
+			return err
+		}
+
+		p := tea.NewProgram(
+			tui.New(root, settings, "", tui.NewAnnotations()),
+			tea.WithAltScreen(),
+			tea.WithMouseAllMotion(),
+		)
+
+		slog.Info("Starting TUI program")
+		if _, err := p.Run(); err != nil {
+			slog.Error("TUI program failed", "error", err)
+			return err
+		}
+
+		slog.Info("Kyma transitions preview ended")
+		return nil
+	},
+}
+
+// transitionsPreview returns a deck with a slide playing each of the named
+// transitions.
+func transitionsPreview(names []string) string {
+	var b strings.Builder
+
+	b.WriteString("# Transitions\n\n")
+	b.WriteString("Move to the next slide to play a transition, and back to play it backwards.\n\n")
+	b.WriteString("Set one on a slide with `transition: <name>` in its front matter.\n")
+
+	for i, name := range names {
+		fmt.Fprintf(&b, "----\n---\ntransition: %s\n---\n", name)
+		fmt.Fprintf(&b, "# %s\n\n", name)
+		fmt.Fprintf(&b, "Transition %d of %d\n", i+1, len(names))
+	}
+
+	return b.String()
+}
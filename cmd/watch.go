@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/museslabs/kyma/internal/config"
+	"github.com/museslabs/kyma/internal/deck"
+	"github.com/museslabs/kyma/internal/tui"
+)
+
+// dependencyKind describes how a change to a file the deck depends on is
+// handled.
+type dependencyKind uint8
+
+const (
+	// dependencySource files affect how the deck is parsed (the deck itself,
+	// the config file, custom themes), so a change reloads the whole deck.
+	dependencySource dependencyKind = iota
+	// dependencyAsset files are only read while rendering (images), so a
+	// change evicts the caches of the slides using them.
+	dependencyAsset
+	// dependencyDirectory is a directory deck, where adding or changing any
+	// markdown file reloads the deck.
+	dependencyDirectory
+)
+
+// dependencies maps the absolute path of every file a deck depends on to its
+// [dependencyKind].
+type dependencies map[string]dependencyKind
+
+func (d dependencies) add(path string, kind dependencyKind) {
+	if path == "" {
+		return
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return
+	}
+
+	// A file used both as a source and as an asset needs a full reload.
+	if existing, ok := d[abs]; ok && existing < kind {
+		return
+	}
+	d[abs] = kind
+}
+
+// match returns the dependency an event on name refers to. Editors often save
+// through backup or swap files next to the original, so those count as well.
+func (d dependencies) match(name string) (string, dependencyKind, bool) {
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return "", 0, false
+	}
+
+	if kind, ok := d[abs]; ok {
+		return abs, kind, true
+	}
+
+	dir := filepath.Dir(abs)
+	if kind, ok := d[dir]; ok && kind == dependencyDirectory && filepath.Ext(abs) == ".md" {
+		return abs, dependencySource, true
+	}
+
+	for path, kind := range d {
+		if kind != dependencyDirectory && isEditorFile(abs, path) {
+			return path, kind, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// isEditorFile reports whether name is a file editors write next to path
+// while saving it: a backup (path~), a vim swap file (.path.swp, .path.swx)
+// or the file vim probes the directory with (4913), or an emacs lock file
+// (.#path).
+func isEditorFile(name, path string) bool {
+	if filepath.Dir(name) != filepath.Dir(path) {
+		return false
+	}
+
+	base := filepath.Base(path)
+	switch filepath.Base(name) {
+	case base + "~", "." + base + ".swp", "." + base + ".swx", "4913", ".#" + base:
+		return true
+	default:
+		return false
+	}
+}
+
+// collectDependencies computes the dependency set of a parsed deck: the deck
+// file and the files it includes (or the directory it was read from), the
+// config file, custom JSON themes and images.
+func collectDependencies(src *deck.Source, root *tui.Slide) dependencies {
+	deps := dependencies{}
+
+	for _, f := range src.Files {
+		deps.add(f, dependencySource)
+	}
+	for _, dir := range src.Dirs {
+		deps.add(dir, dependencyDirectory)
+	}
+	deps.add(config.File(), dependencySource)
+
+	for slide := root; slide != nil; slide = slide.Next {
+		if theme, ok := slide.Properties.Style.Theme.File(); ok {
+			deps.add(theme, dependencySource)
+		}
+		for _, img := range slide.Images() {
+			deps.add(img, dependencyAsset)
+		}
+	}
+
+	return deps
+}
+
+type deckWatcher struct {
+	watcher    *fsnotify.Watcher
+	program    *tea.Program
+	filename   string
+	configPath string
+
+	mu   sync.Mutex
+	deps dependencies
+	dirs map[string]struct{}
+}
+
+func newDeckWatcher(
+	watcher *fsnotify.Watcher,
+	p *tea.Program,
+	filename, configPath string,
+	src *deck.Source,
+	root *tui.Slide,
+) *deckWatcher {
+	w := &deckWatcher{
+		watcher:    watcher,
+		program:    p,
+		filename:   filename,
+		configPath: configPath,
+		dirs:       map[string]struct{}{},
+	}
+	w.setDependencies(collectDependencies(src, root))
+	return w
+}
+
+// setDependencies replaces the watched dependency set and starts watching the
+// directory of every dependency. Directories are watched rather than files so
+// that editors replacing files on save keep being picked up.
+func (w *deckWatcher) setDependencies(deps dependencies) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.deps = deps
+
+	for path, kind := range deps {
+		dir := filepath.Dir(path)
+		if kind == dependencyDirectory {
+			dir = path
+		}
+		if _, ok := w.dirs[dir]; ok {
+			continue
+		}
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+		if err := w.watcher.Add(dir); err != nil {
+			slog.Error("Failed to watch directory", "error", err, "dir", dir)
+			continue
+		}
+		w.dirs[dir] = struct{}{}
+	}
+}
+
+func (w *deckWatcher) match(name string) (string, dependencyKind, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.deps.match(name)
+}
+
+func (w *deckWatcher) run() {
+	var (
+		debounceTimer *time.Timer
+		pendingMu     sync.Mutex
+		reload        bool
+		assets        = map[string]struct{}{}
+	)
+
+	flush := func() {
+		pendingMu.Lock()
+		doReload := reload
+		changed := make([]string, 0, len(assets))
+		for path := range assets {
+			changed = append(changed, path)
+		}
+		reload = false
+		assets = map[string]struct{}{}
+		pendingMu.Unlock()
+
+		if doReload {
+			w.reload()
+			return
+		}
+		if len(changed) > 0 {
+			slog.Info("Assets changed, refreshing affected slides", "files", changed)
+			w.program.Send(tui.AssetsChangedMsg{Paths: changed})
+		}
+	}
+
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+				continue
+			}
+
+			path, kind, ok := w.match(event.Name)
+			if !ok {
+				continue
+			}
+
+			pendingMu.Lock()
+			if kind == dependencySource {
+				reload = true
+			} else {
+				assets[path] = struct{}{}
+			}
+			pendingMu.Unlock()
+
+			if debounceTimer != nil {
+				debounceTimer.Stop()
+			}
+			debounceTimer = time.AfterFunc(100*time.Millisecond, flush)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.program.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err)})
+		}
+	}
+}
+
+func (w *deckWatcher) reload() {
+	slog.Info("File changed, reloading presentation", "file", w.filename)
+
+	src, err := deck.Load(w.filename)
+	if err != nil {
+		slog.Error("Failed to read file during reload", "error", err, "filename", w.filename)
+		w.program.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err)})
+		return
+	}
+
+	if err := config.Load(w.configPath); err != nil {
+		slog.Error("Failed to reload config", "error", err, "config_path", w.configPath)
+		w.program.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err)})
+		return
+	}
+
+	// Custom themes may have changed on disk, drop their generated chroma
+	// styles before parsing again.
+	config.ForgetCustomThemes()
+
+	newRoot, settings, err := parseSlides(src)
+	if err != nil {
+		slog.Error("Failed to parse slides during reload", "error", err, "filename", w.filename)
+		w.program.Send(tui.UpdateSlidesMsg{NewRoot: createErrorSlide(err)})
+		return
+	}
+
+	w.setDependencies(collectDependencies(src, newRoot))
+
+	slog.Info("Successfully reloaded presentation")
+	w.program.Send(tui.UpdateSlidesMsg{NewRoot: newRoot, Deck: settings})
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	progressBar  = "progress"
+	progressFill = "━"
+	progressRest = "─"
+)
+
+// BarConfig is a header or footer line rendered inside the slide frame.
+type BarConfig struct {
+	// Template is rendered with a [BarData] to produce the bar's text.
+	Template *template.Template
+	// Progress renders a progress bar spanning the whole slide instead of text.
+	Progress bool
+	// Disabled hides the bar, even if the global config or the deck set one.
+	Disabled bool
+}
+
+// BarData is the data header and footer templates are executed with.
+type BarData struct {
+	SlideNumber int
+	Total       int
+	Title       string
+	DeckTitle   string
+	Author      string
+	// Elapsed is the time since the presentation started, as mm:ss.
+	Elapsed string
+}
+
+// Progress returns a progress bar width cells wide showing how far into the
+// deck the slide is. Templates can use it as {{ .Progress 20 }}.
+func (d BarData) Progress(width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	filled := width
+	if d.Total > 0 {
+		filled = width * d.SlideNumber / d.Total
+	}
+	filled = min(max(filled, 0), width)
+
+	return strings.Repeat(progressFill, filled) + strings.Repeat(progressRest, width-filled)
+}
+
+// ParseBar parses the value of a header or footer setting: a template, the
+// word "progress" for a progress bar, or false to disable the bar.
+func ParseBar(value any) (*BarConfig, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		if v {
+			return nil, fmt.Errorf("bar must be a template, %q or false", progressBar)
+		}
+		return &BarConfig{Disabled: true}, nil
+	case string:
+		if strings.TrimSpace(v) == progressBar {
+			return &BarConfig{Progress: true}, nil
+		}
+
+		tmpl, err := template.New("bar").Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		// Catch references to unknown fields now rather than on every render
+		if err := tmpl.Execute(&strings.Builder{}, BarData{}); err != nil {
+			return nil, err
+		}
+		return &BarConfig{Template: tmpl}, nil
+	default:
+		return nil, fmt.Errorf("invalid bar %v", value)
+	}
+}
+
+func (b *BarConfig) UnmarshalYAML(bytes []byte) error {
+	var value any
+	if err := yaml.Unmarshal(bytes, &value); err != nil {
+		return err
+	}
+
+	bar, err := ParseBar(value)
+	if err != nil {
+		return err
+	}
+	if bar != nil {
+		*b = *bar
+	}
+
+	return nil
+}
+
+// Render returns the bar width cells wide, or an empty string if there is no
+// bar to show.
+func (b *BarConfig) Render(width int, data BarData) string {
+	if b == nil || b.Disabled || width <= 0 {
+		return ""
+	}
+
+	if b.Progress {
+		return data.Progress(width)
+	}
+
+	var out strings.Builder
+	if err := b.Template.Execute(&out, data); err != nil {
+		return err.Error()
+	}
+
+	return lipgloss.NewStyle().
+		Width(width).
+		MaxWidth(width).
+		MaxHeight(1).
+		Align(lipgloss.Center).
+		Render(out.String())
+}
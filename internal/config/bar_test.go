@@ -0,0 +1,140 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseBar(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        any
+		wantNil      bool
+		wantDisabled bool
+		wantProgress bool
+		wantErr      bool
+	}{
+		{name: "unset", value: nil, wantNil: true},
+		{name: "disabled", value: false, wantDisabled: true},
+		{name: "true is not a bar", value: true, wantErr: true},
+		{name: "progress", value: "progress", wantProgress: true},
+		{name: "template", value: "{{ .SlideNumber }}/{{ .Total }}"},
+		{name: "unknown field", value: "{{ .Missing }}", wantErr: true},
+		{name: "invalid template", value: "{{ .SlideNumber", wantErr: true},
+		{name: "invalid type", value: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bar, err := ParseBar(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (bar == nil) != tt.wantNil {
+				t.Fatalf("ParseBar() = %v, want nil %v", bar, tt.wantNil)
+			}
+			if bar == nil {
+				return
+			}
+			if bar.Disabled != tt.wantDisabled || bar.Progress != tt.wantProgress {
+				t.Errorf("ParseBar() = %+v", bar)
+			}
+		})
+	}
+}
+
+func TestBarConfig_Render(t *testing.T) {
+	data := BarData{SlideNumber: 2, Total: 4, DeckTitle: "Talk"}
+
+	bar, err := ParseBar("{{ .DeckTitle }} {{ .SlideNumber }}/{{ .Total }}")
+	if err != nil {
+		t.Fatalf("ParseBar() error = %v", err)
+	}
+	if got := bar.Render(20, data); strings.TrimSpace(got) != "Talk 2/4" || lipgloss.Width(got) != 20 {
+		t.Errorf("Render() = %q, want %q centered in 20 cells", got, "Talk 2/4")
+	}
+
+	progress := &BarConfig{Progress: true}
+	if got, want := progress.Render(8, data), "━━━━────"; got != want {
+		t.Errorf("Render() progress = %q, want %q", got, want)
+	}
+
+	disabled := &BarConfig{Disabled: true}
+	if got := disabled.Render(20, data); got != "" {
+		t.Errorf("Render() disabled = %q, want empty", got)
+	}
+
+	var unset *BarConfig
+	if got := unset.Render(20, data); got != "" {
+		t.Errorf("Render() nil = %q, want empty", got)
+	}
+}
+
+func TestSlideStyle_RenderBars(t *testing.T) {
+	style := StyleConfig{}.Apply(30, 10)
+
+	out := style.Render("content", "header", "footer")
+	if h := lipgloss.Height(out); h != 10 {
+		t.Errorf("Render() height = %d, want 10", h)
+	}
+
+	lines := strings.Split(out, "\n")
+	if !strings.Contains(lines[1], "header") {
+		t.Errorf("header should be on the first line inside the frame, got %q", lines[1])
+	}
+	if !strings.Contains(lines[len(lines)-2], "footer") {
+		t.Errorf("footer should be on the last line inside the frame, got %q", lines[len(lines)-2])
+	}
+}
+
+func TestBarCascade(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testConfig := `global:
+  footer: "{{ .SlideNumber }}/{{ .Total }}"
+`
+	testConfigPath := filepath.Join(tmpDir, "kyma.yaml")
+	if err := os.WriteFile(testConfigPath, []byte(testConfig), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if err := Load(testConfigPath); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	t.Cleanup(func() {
+		GlobalConfig.Global.Footer = nil
+		_ = LoadDeck("")
+	})
+
+	if err := LoadDeck("header: progress"); err != nil {
+		t.Fatalf("LoadDeck() error = %v", err)
+	}
+
+	p, err := NewProperties("")
+	if err != nil {
+		t.Fatalf("NewProperties() error = %v", err)
+	}
+	if p.Footer == nil || p.Footer.Template == nil {
+		t.Errorf("footer should come from the global config, got %+v", p.Footer)
+	}
+	if p.Header == nil || !p.Header.Progress {
+		t.Errorf("header should come from the deck, got %+v", p.Header)
+	}
+
+	p, err = NewProperties("footer: false")
+	if err != nil {
+		t.Fatalf("NewProperties() error = %v", err)
+	}
+	if p.Footer == nil || !p.Footer.Disabled {
+		t.Errorf("footer should be disabled by the slide, got %+v", p.Footer)
+	}
+	if p.Header == nil || !p.Header.Progress {
+		t.Errorf("header should still come from the deck, got %+v", p.Header)
+	}
+}
@@ -15,18 +15,42 @@ import (
 const (
 	configName = "kyma"
 	configType = "yaml"
+
+	// reducedMotionEnv overrides the reduced_motion setting of the config.
+	reducedMotionEnv = "KYMA_REDUCED_MOTION"
 )
 
+// GlobalConfig is the config loaded by [Load]. It is only read while the
+// presentation is parsed, what is shown taking its settings along with the
+// slides.
 var GlobalConfig config
 
 type config struct {
-	Global  presetConfig            `mapstructure:"global"`
-	Presets map[string]presetConfig `mapstructure:"presets"`
+	Global        presetConfig            `mapstructure:"global"`
+	Presets       map[string]presetConfig `mapstructure:"presets"`
+	ReducedMotion string                  `mapstructure:"reduced_motion"`
+	ImageCache    ImageCacheConfig        `mapstructure:"image_cache"`
+}
+
+// ImageCacheConfig sets how the drawings of images are cached.
+type ImageCacheConfig struct {
+	// Memory is how much memory drawings are kept in, in megabytes, or 0 for
+	// the default.
+	Memory int `mapstructure:"memory"`
+	// Disk also keeps drawings in the cache directory of the user, for later
+	// runs not to draw them again.
+	Disk bool `mapstructure:"disk"`
+	// DiskSize is how much disk space drawings are kept in, in megabytes,
+	// or 0 for the default.
+	DiskSize int `mapstructure:"disk_size"`
 }
 
 type presetConfig struct {
-	Style      StyleConfig            `mapstructure:"style"`
-	Transition transitions.Transition `mapstructure:"transition"`
+	Style          StyleConfig            `mapstructure:"style"`
+	Transition     transitions.Transition `mapstructure:"transition"`
+	JumpTransition transitions.Transition `mapstructure:"jump_transition"`
+	Header         *BarConfig             `mapstructure:"header"`
+	Footer         *BarConfig             `mapstructure:"footer"`
 }
 
 func styleConfigDecodeHook() mapstructure.DecodeHookFunc {
@@ -48,10 +72,22 @@ func styleConfigDecodeHook() mapstructure.DecodeHookFunc {
 
 func transitionDecodeHook() mapstructure.DecodeHookFunc {
 	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
-		if from.Kind() == reflect.String &&
-			to == reflect.TypeOf((*transitions.Transition)(nil)).Elem() {
-			name := data.(string)
-			return transitions.Get(name, transitions.Fps), nil
+		if to == reflect.TypeOf((*transitions.Transition)(nil)).Elem() &&
+			(from.Kind() == reflect.String || from.Kind() == reflect.Map) {
+			return ParseTransition(data)
+		}
+		return data, nil
+	}
+}
+
+func barDecodeHook() mapstructure.DecodeHookFunc {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if to == reflect.TypeOf(BarConfig{}) {
+			bar, err := ParseBar(data)
+			if err != nil || bar == nil {
+				return data, err
+			}
+			return *bar, nil
 		}
 		return data, nil
 	}
@@ -87,12 +123,16 @@ func Load(configPath string) error {
 		return fmt.Errorf("failed to read config: %w", err)
 	}
 
+	// The config is decoded aside, for a config failing to load not to
+	// replace the one loaded before it
+	var c config
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			styleConfigDecodeHook(),
 			transitionDecodeHook(),
+			barDecodeHook(),
 		),
-		Result:  &GlobalConfig,
+		Result:  &c,
 		TagName: "mapstructure",
 	})
 	if err != nil {
@@ -103,9 +143,47 @@ func Load(configPath string) error {
 		return err
 	}
 
+	if _, err := transitions.ParseReducedMotion(c.ReducedMotion); err != nil {
+		return fmt.Errorf("reduced_motion: %w", err)
+	}
+	if c.ImageCache.Memory < 0 {
+		return fmt.Errorf("image_cache.memory: must not be negative, got %d", c.ImageCache.Memory)
+	}
+	if c.ImageCache.DiskSize < 0 {
+		return fmt.Errorf("image_cache.disk_size: must not be negative, got %d", c.ImageCache.DiskSize)
+	}
+
+	GlobalConfig = c
 	return nil
 }
 
+// ReducedMotion returns how transitions are toned down, set by the
+// KYMA_REDUCED_MOTION environment variable or else by the reduced_motion
+// setting of the config.
+func ReducedMotion() (transitions.ReducedMotion, error) {
+	if env, ok := os.LookupEnv(reducedMotionEnv); ok {
+		r, err := transitions.ParseReducedMotion(env)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", reducedMotionEnv, err)
+		}
+		return r, nil
+	}
+	return transitions.ParseReducedMotion(GlobalConfig.ReducedMotion)
+}
+
+// File returns the absolute path of the config file loaded by [Load], or an
+// empty string if no config has been loaded yet.
+func File() string {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
 func createDefaultConfig(home string) error {
 	configDir := filepath.Join(home, ".config", "kyma")
 	configFile := filepath.Join(configDir, fmt.Sprintf("%s.%s", configName, configType))
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	glamourStyles "github.com/charmbracelet/glamour/styles"
@@ -296,7 +298,7 @@ theme: dracula`,
 						Name:  "dracula",
 					},
 				},
-				Transition:   transitions.Get("none", transitions.Fps),
+				Transition:   transitions.None(),
 				Notes:        "",
 				ImageBackend: "chafa",
 			},
@@ -324,7 +326,7 @@ theme: dracula`,
 						Name:  "dracula",
 					},
 				},
-				Transition:   transitions.Get("none", transitions.Fps),
+				Transition:   transitions.None(),
 				Notes:        "",
 				ImageBackend: "chafa",
 			},
@@ -352,7 +354,7 @@ theme: dracula`,
 						Name:  "dracula",
 					},
 				},
-				Transition:   transitions.Get("none", transitions.Fps),
+				Transition:   transitions.None(),
 				Notes:        "",
 				ImageBackend: "chafa",
 			},
@@ -380,7 +382,7 @@ theme: dracula`,
 						Name:  "dracula",
 					},
 				},
-				Transition:   transitions.Get("none", transitions.Fps),
+				Transition:   transitions.None(),
 				Notes:        "",
 				ImageBackend: "chafa",
 			},
@@ -408,7 +410,7 @@ layout: left`,
 						Name:  "dark",
 					},
 				},
-				Transition:   transitions.Get("none", transitions.Fps),
+				Transition:   transitions.None(),
 				Notes:        "",
 				ImageBackend: "chafa",
 			},
@@ -433,7 +435,7 @@ layout: left`,
 						Name:  "notty",
 					},
 				},
-				Transition:   transitions.Get("none", transitions.Fps),
+				Transition:   transitions.None(),
 				Notes:        "",
 				ImageBackend: "chafa",
 			},
@@ -460,7 +462,7 @@ style:
 						Name:  "notty",
 					},
 				},
-				Transition:   transitions.Get("none", transitions.Fps),
+				Transition:   transitions.None(),
 				Notes:        "",
 				ImageBackend: "chafa",
 			},
@@ -518,3 +520,133 @@ style:
 		})
 	}
 }
+
+func TestProperties_ShownTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		props    Properties
+		audience string
+		want     bool
+	}{
+		{name: "regular slide", props: Properties{}, want: true},
+		{name: "regular slide with audience", props: Properties{}, audience: "external", want: true},
+		{name: "hidden", props: Properties{Hidden: true}, want: false},
+		{
+			name:  "audience slide without audience selected",
+			props: Properties{Audience: []string{"internal"}},
+			want:  true,
+		},
+		{
+			name:     "matching audience",
+			props:    Properties{Audience: []string{"internal", "partners"}},
+			audience: "partners",
+			want:     true,
+		},
+		{
+			name:     "other audience",
+			props:    Properties{Audience: []string{"internal"}},
+			audience: "external",
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.props.ShownTo(tt.audience); got != tt.want {
+				t.Errorf("ShownTo(%q) = %v, want %v", tt.audience, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_ImageCache(t *testing.T) {
+	t.Cleanup(func() { GlobalConfig = config{} })
+
+	tests := []struct {
+		name    string
+		config  string
+		want    ImageCacheConfig
+		wantErr bool
+	}{
+		{name: "default", config: "global: {}\n"},
+		{
+			name:   "set",
+			config: "image_cache:\n  memory: 128\n  disk: true\n  disk_size: 1024\n",
+			want:   ImageCacheConfig{Memory: 128, Disk: true, DiskSize: 1024},
+		},
+		{name: "negative memory", config: "image_cache:\n  memory: -1\n", wantErr: true},
+		{name: "negative disk size", config: "image_cache:\n  disk_size: -1\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			GlobalConfig = config{}
+			path := filepath.Join(t.TempDir(), "kyma.yaml")
+			if err := os.WriteFile(path, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && GlobalConfig.ImageCache != tt.want {
+				t.Errorf("ImageCache = %+v, want %+v", GlobalConfig.ImageCache, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_KeepsConfigOnError(t *testing.T) {
+	t.Cleanup(func() { GlobalConfig = config{} })
+
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.yaml")
+	bad := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(good, []byte("image_cache:\n  memory: 64\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bad, []byte("image_cache:\n  memory: -1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Load(good); err != nil {
+		t.Fatalf("Load(good) error = %v", err)
+	}
+	if err := Load(bad); err == nil {
+		t.Fatal("Load(bad) error = nil, want an error")
+	}
+	if GlobalConfig.ImageCache.Memory != 64 {
+		t.Errorf("ImageCache.Memory = %d, want the 64 loaded before", GlobalConfig.ImageCache.Memory)
+	}
+}
+
+func TestGetChromaStyle_ForgetConcurrently(t *testing.T) {
+	data, err := json.Marshal(glamourStyles.DarkStyleConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	theme := filepath.Join(t.TempDir(), "theme.json")
+	if err := os.WriteFile(theme, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(ForgetCustomThemes)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			if GetChromaStyle(theme) == nil {
+				t.Error("GetChromaStyle() = nil")
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			ForgetCustomThemes()
+		}
+	}()
+	wg.Wait()
+}
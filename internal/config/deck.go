@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/museslabs/kyma/internal/tui/transitions"
+)
+
+// Deck holds the settings of the presentation being parsed. Its style,
+// transition and preset apply to every slide, between the global config and
+// each slide's front matter. It is written again when the presentation is
+// reloaded, so what is shown reads a copy taken with the slides instead.
+var Deck DeckConfig
+
+type DeckConfig struct {
+	Title  string
+	Author string
+	Date   string
+	Event  string
+	// Duration is the time the presentation is expected to take, or zero if
+	// there is no target.
+	Duration time.Duration
+
+	Style          StyleConfig
+	Transition     transitions.Transition
+	JumpTransition transitions.Transition
+	Preset         string
+	Header         *BarConfig
+	Footer         *BarConfig
+
+	// Vars holds user-defined values slides can reference in templates.
+	Vars map[string]any
+}
+
+// UnmarshalYAML decodes deck settings on top of the current ones, so that
+// several documents can be layered: only the keys present in bytes override
+// what is already set.
+func (d *DeckConfig) UnmarshalYAML(bytes []byte) error {
+	aux := struct {
+		Title          string         `yaml:"title"`
+		Author         string         `yaml:"author"`
+		Date           string         `yaml:"date"`
+		Event          string         `yaml:"event"`
+		Duration       string         `yaml:"duration"`
+		Style          StyleConfig    `yaml:"style"`
+		Transition     any            `yaml:"transition"`
+		JumpTransition any            `yaml:"jump_transition"`
+		Preset         string         `yaml:"preset"`
+		Header         *BarConfig     `yaml:"header"`
+		Footer         *BarConfig     `yaml:"footer"`
+		Vars           map[string]any `yaml:"vars"`
+	}{}
+
+	if err := yaml.Unmarshal(bytes, &aux); err != nil {
+		return err
+	}
+
+	if aux.Preset != "" {
+		if _, ok := GlobalConfig.Presets[aux.Preset]; !ok {
+			return fmt.Errorf("preset %s does not exist", aux.Preset)
+		}
+		d.Preset = aux.Preset
+	}
+
+	if aux.Duration != "" {
+		duration, err := time.ParseDuration(aux.Duration)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", aux.Duration, err)
+		}
+		d.Duration = duration
+	}
+
+	if aux.Title != "" {
+		d.Title = aux.Title
+	}
+	if aux.Author != "" {
+		d.Author = aux.Author
+	}
+	if aux.Date != "" {
+		d.Date = aux.Date
+	}
+	if aux.Event != "" {
+		d.Event = aux.Event
+	}
+	transition, err := ParseTransition(aux.Transition)
+	if err != nil {
+		return err
+	}
+	if transition != nil {
+		d.Transition = transition
+	}
+	jumpTransition, err := ParseTransition(aux.JumpTransition)
+	if err != nil {
+		return fmt.Errorf("jump_transition: %w", err)
+	}
+	if jumpTransition != nil {
+		d.JumpTransition = jumpTransition
+	}
+	if aux.Header != nil {
+		d.Header = aux.Header
+	}
+	if aux.Footer != nil {
+		d.Footer = aux.Footer
+	}
+	for name, value := range aux.Vars {
+		if d.Vars == nil {
+			d.Vars = map[string]any{}
+		}
+		d.Vars[name] = value
+	}
+	d.Style.Merge(aux.Style)
+
+	return nil
+}
+
+// LoadDeck replaces [Deck] with the settings in headers, YAML documents
+// applied in order so that later ones take precedence. No headers resets the
+// deck settings.
+func LoadDeck(headers ...string) error {
+	var d DeckConfig
+	for _, header := range headers {
+		if err := yaml.Unmarshal([]byte(header), &d); err != nil {
+			return err
+		}
+	}
+
+	Deck = d
+	return nil
+}
+
+// deckDefaults returns the style, transition and bars slides start from: the global
+// config, overridden by the deck's preset and then by the deck's own settings.
+func deckDefaults() presetConfig {
+	defaults := GlobalConfig.Global
+
+	if preset, ok := GlobalConfig.Presets[Deck.Preset]; ok {
+		defaults.Style.Merge(preset.Style)
+		if preset.Transition != nil {
+			defaults.Transition = preset.Transition
+		}
+		if preset.JumpTransition != nil {
+			defaults.JumpTransition = preset.JumpTransition
+		}
+		if preset.Header != nil {
+			defaults.Header = preset.Header
+		}
+		if preset.Footer != nil {
+			defaults.Footer = preset.Footer
+		}
+	}
+
+	defaults.Style.Merge(Deck.Style)
+	if Deck.Transition != nil {
+		defaults.Transition = Deck.Transition
+	}
+	if Deck.JumpTransition != nil {
+		defaults.JumpTransition = Deck.JumpTransition
+	}
+	if Deck.Header != nil {
+		defaults.Header = Deck.Header
+	}
+	if Deck.Footer != nil {
+		defaults.Footer = Deck.Footer
+	}
+
+	return defaults
+}
+
+// JumpTransition returns the transition played when moving between slides
+// that are not next to each other.
+func JumpTransition() transitions.Transition {
+	if transition := deckDefaults().JumpTransition; transition != nil {
+		return transition
+	}
+	return transitions.None()
+}
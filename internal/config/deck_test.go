@@ -0,0 +1,189 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/goccy/go-yaml"
+
+	"github.com/museslabs/kyma/internal/tui/transitions"
+)
+
+func TestDeckCascade(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testConfig := `global:
+  style:
+    border: rounded
+    border_color: "#FF0000"
+    theme: dark
+  transition: none
+
+presets:
+  animated:
+    style:
+      border_color: "#00FF00"
+    transition: slideUp
+`
+	testConfigPath := filepath.Join(tmpDir, "kyma.yaml")
+	if err := os.WriteFile(testConfigPath, []byte(testConfig), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if err := Load(testConfigPath); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	t.Cleanup(func() { _ = LoadDeck("") })
+
+	tests := []struct {
+		name           string
+		header         string
+		properties     string
+		wantBorder     lipgloss.Border
+		wantColor      string
+		wantTransition string
+	}{
+		{
+			name:           "no deck settings",
+			properties:     "title: x",
+			wantBorder:     lipgloss.RoundedBorder(),
+			wantColor:      "#FF0000",
+			wantTransition: "none",
+		},
+		{
+			name:           "deck overrides global",
+			header:         "style:\n  border: hidden\ntransition: swipeLeft",
+			properties:     "title: x",
+			wantBorder:     lipgloss.HiddenBorder(),
+			wantColor:      "#FF0000",
+			wantTransition: "none",
+		},
+		{
+			name:           "deck applies to slides without front matter",
+			header:         "style:\n  border: hidden\ntransition: swipeLeft",
+			wantBorder:     lipgloss.HiddenBorder(),
+			wantColor:      "#FF0000",
+			wantTransition: "swipeLeft",
+		},
+		{
+			name:           "deck preset",
+			header:         "preset: animated",
+			wantBorder:     lipgloss.RoundedBorder(),
+			wantColor:      "#00FF00",
+			wantTransition: "slideUp",
+		},
+		{
+			name:           "slide overrides deck",
+			header:         "style:\n  border: hidden\ntransition: swipeLeft",
+			properties:     "transition: slideDown\nstyle:\n  border: double",
+			wantBorder:     lipgloss.DoubleBorder(),
+			wantColor:      "#FF0000",
+			wantTransition: "slideDown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := LoadDeck(tt.header); err != nil {
+				t.Fatalf("LoadDeck() error = %v", err)
+			}
+
+			p, err := NewProperties(tt.properties)
+			if err != nil {
+				t.Fatalf("NewProperties() error = %v", err)
+			}
+
+			if *p.Style.Border != tt.wantBorder {
+				t.Errorf("p.Style.Border = %v, want = %v", *p.Style.Border, tt.wantBorder)
+			}
+			if p.Style.BorderColor != tt.wantColor {
+				t.Errorf("p.Style.BorderColor = %s, want = %s", p.Style.BorderColor, tt.wantColor)
+			}
+			if p.Transition.Name() != tt.wantTransition {
+				t.Errorf("p.Transition = %s, want = %s", p.Transition.Name(), tt.wantTransition)
+			}
+		})
+	}
+}
+
+func TestLoadDeck(t *testing.T) {
+	t.Cleanup(func() { _ = LoadDeck("") })
+
+	if err := LoadDeck("title: Talk\nauthor: Someone\norder: [a.md]"); err != nil {
+		t.Fatalf("LoadDeck() error = %v", err)
+	}
+	if Deck.Title != "Talk" || Deck.Author != "Someone" {
+		t.Errorf("Deck = %+v, want title and author set", Deck)
+	}
+
+	if err := LoadDeck("title: Talk\nduration: 20m", "title: Override\nevent: Meetup"); err != nil {
+		t.Fatalf("LoadDeck() error = %v", err)
+	}
+	if Deck.Title != "Override" || Deck.Event != "Meetup" || Deck.Duration != 20*time.Minute {
+		t.Errorf("Deck = %+v, want layered settings", Deck)
+	}
+
+	if err := LoadDeck("duration: soon"); err == nil {
+		t.Error("LoadDeck() with an invalid duration should fail")
+	}
+
+	if err := LoadDeck("preset: missing"); err == nil {
+		t.Error("LoadDeck() with an unknown preset should fail")
+	}
+
+	var d DeckConfig
+	if err := yaml.Unmarshal([]byte("transition: flip"), &d); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	if d.Transition != getTransition(t, "flip", transitions.Params{}) {
+		t.Errorf("d.Transition = %v, want flip", d.Transition)
+	}
+}
+
+func TestJumpTransition(t *testing.T) {
+	GlobalConfig = config{}
+	t.Cleanup(func() {
+		GlobalConfig = config{}
+		_ = LoadDeck("")
+	})
+
+	if got := JumpTransition().Name(); got != "none" {
+		t.Errorf("JumpTransition() without config = %s, want none", got)
+	}
+
+	testConfig := `global:
+  transition: none
+  jump_transition: flip
+
+presets:
+  animated:
+    jump_transition: slideUp
+`
+	testConfigPath := filepath.Join(t.TempDir(), "kyma.yaml")
+	if err := os.WriteFile(testConfigPath, []byte(testConfig), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if err := Load(testConfigPath); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: "flipRight"},
+		{header: "preset: animated", want: "slideUp"},
+		{header: "preset: animated\njump_transition: swipeLeft", want: "swipeLeft"},
+	}
+
+	for _, tt := range tests {
+		if err := LoadDeck(tt.header); err != nil {
+			t.Fatalf("LoadDeck(%q) error = %v", tt.header, err)
+		}
+		if got := JumpTransition().Name(); got != tt.want {
+			t.Errorf("JumpTransition() with %q = %s, want %s", tt.header, got, tt.want)
+		}
+	}
+}
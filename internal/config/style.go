@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/alecthomas/chroma/v2"
 	chromaStyles "github.com/alecthomas/chroma/v2/styles"
@@ -23,13 +25,46 @@ const (
 )
 
 type Properties struct {
+	// ID identifies the slide in links, as #id. The slug of the title works
+	// too.
+	ID           string                 `yaml:"id"`
 	Title        string                 `yaml:"title"`
 	Style        StyleConfig            `yaml:"style"`
 	Transition   transitions.Transition `yaml:"transition"`
 	Notes        string                 `yaml:"notes"`
 	ImageBackend string                 `yaml:"image_backend"`
+	Header       *BarConfig             `yaml:"header"`
+	Footer       *BarConfig             `yaml:"footer"`
+	// Section is the name of the section the slide belongs to. Slides without
+	// one belong to the section of the slide before them.
+	Section string `yaml:"section"`
+	// Type is the kind of slide, empty for regular markdown slides.
+	Type SlideType `yaml:"type"`
+	// Hidden slides are skipped when presenting.
+	Hidden bool `yaml:"hidden"`
+	// Audience lists the audiences the slide is meant for, empty for all.
+	Audience []string `yaml:"audience"`
 }
 
+// ShownTo reports whether the slide is presented to audience. With no
+// audience selected, only hidden slides are left out.
+func (p Properties) ShownTo(audience string) bool {
+	if p.Hidden {
+		return false
+	}
+	if audience == "" || len(p.Audience) == 0 {
+		return true
+	}
+	return slices.Contains(p.Audience, audience)
+}
+
+type SlideType string
+
+const (
+	// SlideTypeTOC slides list the sections of the deck after their content.
+	SlideTypeTOC SlideType = "toc"
+)
+
 type SlideStyle struct {
 	LipGlossStyle lipgloss.Style
 	Theme         GlamourTheme
@@ -40,6 +75,18 @@ type GlamourTheme struct {
 	Name  string
 }
 
+// File returns the path of the JSON file the theme was loaded from. Built-in
+// glamour themes are not backed by a file.
+func (t *GlamourTheme) File() (string, bool) {
+	if t == nil || t.Name == "" {
+		return "", false
+	}
+	if _, ok := glamourStyles.DefaultStyles[t.Name]; ok {
+		return "", false
+	}
+	return t.Name, true
+}
+
 type StyleConfig struct {
 	Layout      *lipgloss.Style  `yaml:"layout"`
 	Border      *lipgloss.Border `yaml:"border"`
@@ -166,6 +213,47 @@ func (s StyleConfig) Apply(width, height int) SlideStyle {
 	}
 }
 
+// ContentWidth returns the width available inside the slide frame.
+func (s SlideStyle) ContentWidth() int {
+	return s.LipGlossStyle.GetWidth() - s.LipGlossStyle.GetHorizontalPadding()
+}
+
+// Render draws content inside the slide frame, with the header and footer
+// lines, if any, at the top and bottom of the frame. The content keeps the
+// layout of the slide in the space left between them.
+func (s SlideStyle) Render(content, header, footer string) string {
+	if header == "" && footer == "" {
+		return s.LipGlossStyle.Render(content)
+	}
+
+	height := s.LipGlossStyle.GetHeight() - s.LipGlossStyle.GetVerticalPadding()
+	if header != "" {
+		height -= lipgloss.Height(header)
+	}
+	if footer != "" {
+		height -= lipgloss.Height(footer)
+	}
+
+	body := lipgloss.NewStyle().
+		Width(s.ContentWidth()).
+		Height(max(height, 0)).
+		Align(s.LipGlossStyle.GetAlignHorizontal(), s.LipGlossStyle.GetAlignVertical()).
+		Render(content)
+
+	bar := lipgloss.NewStyle().Foreground(s.LipGlossStyle.GetBorderTopForeground())
+
+	parts := make([]string, 0, 3)
+	if header != "" {
+		parts = append(parts, bar.Render(header))
+	}
+	parts = append(parts, body)
+	if footer != "" {
+		parts = append(parts, bar.Render(footer))
+	}
+
+	return s.LipGlossStyle.Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
 func getBorder(border string) (lipgloss.Border, bool) {
 	switch border {
 	case "rounded":
@@ -255,12 +343,19 @@ func getLayoutPosition(p string) (lipgloss.Position, error) {
 
 func (p *Properties) UnmarshalYAML(bytes []byte) error {
 	aux := struct {
+		ID           string      `yaml:"id"`
 		Title        string      `yaml:"title"`
 		Style        StyleConfig `yaml:"style"`
-		Transition   string      `yaml:"transition"`
+		Transition   any         `yaml:"transition"`
 		Preset       string      `yaml:"preset"`
 		Notes        string      `yaml:"notes"`
 		ImageBackend string      `yaml:"image_backend"`
+		Header       *BarConfig  `yaml:"header"`
+		Footer       *BarConfig  `yaml:"footer"`
+		Section      string      `yaml:"section"`
+		Type         SlideType   `yaml:"type"`
+		Hidden       bool        `yaml:"hidden"`
+		Audience     []string    `yaml:"audience"`
 	}{}
 
 	if err := aux.Style.UnmarshalYAML(bytes); err != nil {
@@ -270,9 +365,22 @@ func (p *Properties) UnmarshalYAML(bytes []byte) error {
 		return err
 	}
 
+	switch aux.Type {
+	case "", SlideTypeTOC:
+	default:
+		return fmt.Errorf("unknown slide type %s", aux.Type)
+	}
+
+	p.ID = aux.ID
 	p.Title = aux.Title
 	p.Notes = aux.Notes
 	p.ImageBackend = aux.ImageBackend
+	p.Section = aux.Section
+	p.Type = aux.Type
+	p.Hidden = aux.Hidden
+	p.Audience = aux.Audience
+
+	defaults := deckDefaults()
 
 	if aux.Preset != "" {
 		preset, ok := GlobalConfig.Presets[aux.Preset]
@@ -282,15 +390,42 @@ func (p *Properties) UnmarshalYAML(bytes []byte) error {
 		preset.Style.Merge(aux.Style)
 		p.Style = preset.Style
 		p.Transition = preset.Transition
+		p.Header = preset.Header
+		p.Footer = preset.Footer
 	} else {
-		style := GlobalConfig.Global.Style
+		style := defaults.Style
 		style.Merge(aux.Style)
 		p.Style = style
-		p.Transition = transitions.Get(aux.Transition, transitions.Fps)
+		transition, err := ParseTransition(aux.Transition)
+		if err != nil {
+			return err
+		}
+		// Slides with front matter but no transition have none, the
+		// defaults only applying to slides without front matter
+		if transition == nil {
+			transition = transitions.None()
+		}
+		p.Transition = transition
+	}
+
+	if aux.Header != nil {
+		p.Header = aux.Header
+	}
+	if aux.Footer != nil {
+		p.Footer = aux.Footer
 	}
 
 	if p.Transition == nil {
-		p.Transition = GlobalConfig.Global.Transition
+		p.Transition = defaults.Transition
+	}
+	if p.Header == nil {
+		p.Header = defaults.Header
+	}
+	if p.Footer == nil {
+		p.Footer = defaults.Footer
+	}
+	if p.Transition == nil {
+		p.Transition = transitions.None()
 	}
 	if p.ImageBackend == "" {
 		p.ImageBackend = "chafa"
@@ -301,9 +436,15 @@ func (p *Properties) UnmarshalYAML(bytes []byte) error {
 
 func NewProperties(properties string) (Properties, error) {
 	if properties == "" {
+		defaults := deckDefaults()
+		if defaults.Transition == nil {
+			defaults.Transition = transitions.None()
+		}
 		return Properties{
-			Style:      GlobalConfig.Global.Style,
-			Transition: GlobalConfig.Global.Transition,
+			Style:      defaults.Style,
+			Transition: defaults.Transition,
+			Header:     defaults.Header,
+			Footer:     defaults.Footer,
 		}, nil
 	}
 
@@ -353,7 +494,33 @@ func ChromaStyle(style ansi.StylePrimitive) string {
 	return s
 }
 
+// chromaStylesMu guards the registry of chroma styles, which slides look up
+// and register styles in as they render while a reload forgets them.
+var chromaStylesMu sync.Mutex
+
+// ForgetCustomThemes drops the chroma styles generated from custom JSON themes
+// so that the next [GetChromaStyle] call builds them again, e.g. after a theme
+// file has changed on disk.
+func ForgetCustomThemes() {
+	chromaStylesMu.Lock()
+	defer chromaStylesMu.Unlock()
+
+	prefix := chromaStyleTheme + "-"
+	for name := range chromaStyles.Registry {
+		themeName, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		if _, builtin := glamourStyles.DefaultStyles[themeName]; !builtin {
+			delete(chromaStyles.Registry, name)
+		}
+	}
+}
+
 func GetChromaStyle(themeName string) *chroma.Style {
+	chromaStylesMu.Lock()
+	defer chromaStylesMu.Unlock()
+
 	customThemeName := chromaStyleTheme + "-" + themeName
 
 	if chromaStyle, ok := chromaStyles.Registry[customThemeName]; ok {
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/museslabs/kyma/internal/tui/transitions"
+)
+
+// ParseTransition returns the transition described by its name, or by a map
+// of its name and parameters such as
+// {name: swipeLeft, frequency: 5, damping: 0.9, fps: 30}. A nil value
+// returns a nil transition.
+func ParseTransition(value any) (transitions.Transition, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return transitions.Get(v, transitions.Params{})
+	case map[string]any:
+		var name string
+		var params transitions.Params
+
+		for key, val := range v {
+			switch key {
+			case "name":
+				s, ok := val.(string)
+				if !ok {
+					return nil, fmt.Errorf("transition name must be a string, got %v", val)
+				}
+				name = s
+			case "frequency", "damping":
+				n, err := number(val)
+				if err != nil {
+					return nil, fmt.Errorf("transition %s: %w", key, err)
+				}
+				if key == "frequency" {
+					params.Frequency = n
+				} else {
+					params.Damping = n
+				}
+			case "fps":
+				n, err := number(val)
+				if err != nil {
+					return nil, fmt.Errorf("transition fps: %w", err)
+				}
+				if n != math.Trunc(n) {
+					return nil, fmt.Errorf("transition fps must be a whole number, got %v", n)
+				}
+				params.Fps = int(n)
+			default:
+				return nil, fmt.Errorf("unknown transition parameter %s", key)
+			}
+		}
+
+		if name == "" {
+			return nil, fmt.Errorf("transition is missing a name")
+		}
+		if err := params.Validate(); err != nil {
+			return nil, fmt.Errorf("transition %s: %w", name, err)
+		}
+
+		return transitions.Get(name, params)
+	default:
+		return nil, fmt.Errorf("invalid transition %v", value)
+	}
+}
+
+// number converts the numbers YAML and viper decode to a float64.
+func number(value any) (float64, error) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	default:
+		return 0, fmt.Errorf("%v is not a number", value)
+	}
+}
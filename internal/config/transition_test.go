@@ -0,0 +1,131 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/museslabs/kyma/internal/tui/transitions"
+)
+
+// getTransition returns the registered transition name, failing the test if
+// there is none.
+func getTransition(t *testing.T, name string, params transitions.Params) transitions.Transition {
+	t.Helper()
+
+	transition, err := transitions.Get(name, params)
+	if err != nil {
+		t.Fatalf("transitions.Get(%q) error = %v", name, err)
+	}
+	return transition
+}
+
+func TestParseTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    transitions.Transition
+		wantErr bool
+	}{
+		{name: "nil", value: nil, want: nil},
+		{name: "name", value: "swipeLeft", want: getTransition(t, "swipeLeft", transitions.Params{})},
+		{
+			name:  "map",
+			value: map[string]any{"name": "swipeLeft", "frequency": uint64(5), "damping": 0.9, "fps": uint64(30)},
+			want:  getTransition(t, "swipeLeft", transitions.Params{Frequency: 5, Damping: 0.9, Fps: 30}),
+		},
+		{name: "map without name", value: map[string]any{"fps": 30}, wantErr: true},
+		{name: "unknown parameter", value: map[string]any{"name": "flip", "speed": 2}, wantErr: true},
+		{name: "negative damping", value: map[string]any{"name": "flip", "damping": -1}, wantErr: true},
+		{name: "critical damping", value: map[string]any{"name": "flip", "damping": 1}, wantErr: true},
+		{name: "fps too high", value: map[string]any{"name": "flip", "fps": 1000}, wantErr: true},
+		{name: "fractional fps", value: map[string]any{"name": "flip", "fps": 29.97}, wantErr: true},
+		{name: "non-numeric frequency", value: map[string]any{"name": "flip", "frequency": "fast"}, wantErr: true},
+		{name: "number", value: 5, wantErr: true},
+		{name: "unknown name", value: "spin", wantErr: true},
+		{name: "map with unknown name", value: map[string]any{"name": "spin"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTransition(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTransition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransitionParams(t *testing.T) {
+	GlobalConfig = config{}
+	t.Cleanup(func() {
+		GlobalConfig = config{}
+		_ = LoadDeck("")
+	})
+
+	testConfig := `global:
+  transition:
+    name: slideUp
+    fps: 30
+`
+	testConfigPath := filepath.Join(t.TempDir(), "kyma.yaml")
+	if err := os.WriteFile(testConfigPath, []byte(testConfig), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if err := Load(testConfigPath); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := getTransition(t, "slideUp", transitions.Params{Fps: 30})
+	if GlobalConfig.Global.Transition != want {
+		t.Errorf("global transition = %v, want %v", GlobalConfig.Global.Transition, want)
+	}
+
+	p, err := NewProperties("transition: {name: swipeRight, frequency: 5, damping: 0.9}")
+	if err != nil {
+		t.Fatalf("NewProperties() error = %v", err)
+	}
+	want = getTransition(t, "swipeRight", transitions.Params{Frequency: 5, Damping: 0.9})
+	if p.Transition != want {
+		t.Errorf("p.Transition = %v, want %v", p.Transition, want)
+	}
+
+	if _, err := NewProperties("transition: {name: swipeRight, fps: 0.5}"); err == nil {
+		t.Error("NewProperties() with an invalid fps should fail")
+	}
+
+	if err := LoadDeck("jump_transition: {name: flip, damping: 0.5}"); err != nil {
+		t.Fatalf("LoadDeck() error = %v", err)
+	}
+	want = getTransition(t, "flip", transitions.Params{Damping: 0.5})
+	if Deck.JumpTransition != want {
+		t.Errorf("Deck.JumpTransition = %v, want %v", Deck.JumpTransition, want)
+	}
+}
+
+func TestReducedMotion(t *testing.T) {
+	GlobalConfig = config{}
+	t.Cleanup(func() { GlobalConfig = config{} })
+
+	if got, err := ReducedMotion(); err != nil || got != transitions.ReducedMotionOff {
+		t.Errorf("ReducedMotion() = %q, %v, want off", got, err)
+	}
+
+	GlobalConfig.ReducedMotion = "fade"
+	if got, err := ReducedMotion(); err != nil || got != transitions.ReducedMotionFade {
+		t.Errorf("ReducedMotion() = %q, %v, want the config", got, err)
+	}
+
+	t.Setenv("KYMA_REDUCED_MOTION", "none")
+	if got, err := ReducedMotion(); err != nil || got != transitions.ReducedMotionNone {
+		t.Errorf("ReducedMotion() = %q, %v, want the environment", got, err)
+	}
+
+	t.Setenv("KYMA_REDUCED_MOTION", "slow")
+	if _, err := ReducedMotion(); err == nil {
+		t.Error("ReducedMotion() with an invalid environment should fail")
+	}
+}
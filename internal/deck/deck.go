@@ -0,0 +1,324 @@
+// Package deck loads the markdown source of a presentation: it resolves
+// include directives, keeps track of where every line came from and splits
+// the result into raw slides.
+package deck
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	slideSeparator       = "----\n"
+	frontMatterDelimiter = "---\n"
+	includeDirective     = "@include"
+)
+
+var ErrIncludeCycle = errors.New("include cycle")
+
+// Position identifies a line in one of the files a deck is made of.
+type Position struct {
+	File string
+	Line int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%s:%d", p.File, p.Line)
+}
+
+// Source is the text of a deck once every include directive has been
+// resolved.
+type Source struct {
+	// Text is the expanded markdown of the deck.
+	Text string
+	// Files lists every file the deck depends on, the root file first.
+	Files []string
+	// Dirs lists the directories whose markdown files make up the deck, for
+	// decks loaded from a directory.
+	Dirs []string
+	// Headers holds the deck-level settings as YAML documents, in increasing
+	// order of precedence: a directory deck's manifest, then the deck header
+	// front matter.
+	Headers []string
+
+	// origins holds the position each line of Text was read from.
+	origins []Position
+	// dir returns the directory of a file the deck was read from.
+	dir func(name string) string
+	// hasHeader reports whether the first front matter block of Text is the
+	// deck header rather than the properties of the first slide.
+	hasHeader bool
+}
+
+// Position returns the origin of the line at the given byte offset in
+// [Source.Text].
+func (s *Source) Position(offset int) Position {
+	if len(s.origins) == 0 {
+		return Position{}
+	}
+
+	line := strings.Count(s.Text[:min(offset, len(s.Text))], "\n")
+	if line >= len(s.origins) {
+		line = len(s.origins) - 1
+	}
+	return s.origins[line]
+}
+
+// Slide is the unparsed source of a single slide.
+type Slide struct {
+	// Body is the markdown content of the slide.
+	Body string
+	// Properties is the YAML front matter of the slide, if any.
+	Properties string
+	// Position is where the slide starts.
+	Position Position
+	// Dir is the directory of the file the slide starts in, which the
+	// relative paths of its images are relative to. It is empty for decks
+	// not read from files.
+	Dir string
+}
+
+// Slides splits the source into slides separated by `----` lines, separating
+// each slide's optional front matter from its body. A deck header is not part
+// of any slide; when nothing but the header precedes the first separator, no
+// slide is produced for it.
+func (s *Source) Slides() []Slide {
+	var slides []Slide
+
+	offset := 0
+	for i, chunk := range strings.Split(s.Text, slideSeparator) {
+		pos := s.Position(offset)
+		offset += len(chunk) + len(slideSeparator)
+
+		body, properties := splitFrontMatter(chunk)
+		if i == 0 && s.hasHeader {
+			if strings.TrimSpace(body) == "" {
+				continue
+			}
+			properties = ""
+		}
+
+		dir := ""
+		if s.dir != nil && pos.File != "" {
+			dir = s.dir(pos.File)
+		}
+
+		slides = append(slides, Slide{
+			Body:       body,
+			Properties: properties,
+			Position:   pos,
+			Dir:        dir,
+		})
+	}
+
+	return slides
+}
+
+// detectHeader checks whether the first front matter block of the deck is a
+// deck header, marked with `deck: true`, and records it in [Source.Headers].
+func (s *Source) detectHeader() {
+	first, _, _ := strings.Cut(s.Text, slideSeparator)
+
+	_, properties := splitFrontMatter(first)
+	if properties == "" {
+		return
+	}
+
+	var header struct {
+		Deck bool `yaml:"deck"`
+	}
+	if err := yaml.Unmarshal([]byte(properties), &header); err != nil || !header.Deck {
+		return
+	}
+
+	s.hasHeader = true
+	s.Headers = append(s.Headers, properties)
+}
+
+func splitFrontMatter(s string) (body, properties string) {
+	body = s
+
+	if strings.HasPrefix(strings.TrimSpace(s), frontMatterDelimiter) {
+		parts := strings.SplitN(s, frontMatterDelimiter, 3)
+		if len(parts) == 3 {
+			properties = parts[1]
+			body = parts[2]
+		}
+	}
+
+	return body, properties
+}
+
+// Load reads the deck at name from the local filesystem, resolving include
+// directives relative to the file that contains them. If name is a directory
+// the deck is made of the markdown files it contains, see [LoadDir].
+func Load(name string) (*Source, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return LoadDir(name)
+	}
+
+	l := newOSLoader()
+	return l.load(name)
+}
+
+func newOSLoader() loader {
+	return loader{
+		read: os.ReadFile,
+		join: filepath.Join,
+		dir:  filepath.Dir,
+		abs:  filepath.Abs,
+	}
+}
+
+// LoadFS is like [Load] but reads the deck and its includes from fsys.
+func LoadFS(fsys fs.FS, name string) (*Source, error) {
+	l := loader{
+		read: func(name string) ([]byte, error) { return fs.ReadFile(fsys, name) },
+		join: path.Join,
+		dir:  path.Dir,
+		abs:  func(name string) (string, error) { return path.Clean(name), nil },
+	}
+	return l.load(name)
+}
+
+type loader struct {
+	read func(name string) ([]byte, error)
+	join func(elem ...string) string
+	dir  func(name string) string
+	abs  func(name string) (string, error)
+
+	src   Source
+	text  strings.Builder
+	stack []string
+	seen  map[string]struct{}
+}
+
+func (l *loader) load(names ...string) (*Source, error) {
+	l.seen = map[string]struct{}{}
+
+	for i, name := range names {
+		if i > 0 {
+			// Each file starts a new slide
+			l.text.WriteString(slideSeparator)
+			l.src.origins = append(l.src.origins, Position{File: name, Line: 0})
+		}
+		if err := l.expand(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	l.src.Text = l.text.String()
+	l.src.dir = l.dir
+	l.src.detectHeader()
+	return &l.src, nil
+}
+
+// expand appends the contents of name to the source, recursively replacing
+// include directives found outside fenced code blocks with the contents of
+// the included file.
+func (l *loader) expand(name string, from *Position) error {
+	abs, err := l.abs(name)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range l.stack {
+		if f == abs {
+			chain := append(append([]string{}, l.stack...), abs)
+			return fmt.Errorf(
+				"%s: %w: %s",
+				from,
+				ErrIncludeCycle,
+				strings.Join(chain, " -> "),
+			)
+		}
+	}
+
+	data, err := l.read(name)
+	if err != nil {
+		if from != nil {
+			return fmt.Errorf("%s: include %s: %w", from, name, err)
+		}
+		return err
+	}
+
+	l.stack = append(l.stack, abs)
+	defer func() { l.stack = l.stack[:len(l.stack)-1] }()
+
+	if _, ok := l.seen[abs]; !ok {
+		l.seen[abs] = struct{}{}
+		l.src.Files = append(l.src.Files, name)
+	}
+
+	var fence string
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	scanner.Buffer(nil, len(data)+1)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		pos := Position{File: name, Line: lineNo}
+
+		if marker, ok := fenceMarker(line); ok {
+			switch {
+			case fence == "":
+				fence = marker
+			case strings.HasPrefix(marker, fence):
+				fence = ""
+			}
+		}
+
+		if target, ok := parseInclude(line); ok && fence == "" {
+			if target == "" {
+				return fmt.Errorf("%s: %s requires a path", pos, includeDirective)
+			}
+			if err := l.expand(l.join(l.dir(name), target), &pos); err != nil {
+				return err
+			}
+			continue
+		}
+
+		l.text.WriteString(line)
+		l.text.WriteByte('\n')
+		l.src.origins = append(l.src.origins, pos)
+	}
+
+	return scanner.Err()
+}
+
+// parseInclude reports whether line is an include directive and returns its
+// target path.
+func parseInclude(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+
+	rest, ok := strings.CutPrefix(line, includeDirective)
+	if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+		return "", false
+	}
+
+	return strings.Trim(strings.TrimSpace(rest), `"'`), true
+}
+
+// fenceMarker returns the backtick or tilde run opening or closing a fenced
+// code block on line.
+func fenceMarker(line string) (string, bool) {
+	line = strings.TrimLeft(line, " ")
+	for _, c := range []string{"`", "~"} {
+		n := len(line) - len(strings.TrimLeft(line, c))
+		if n >= 3 {
+			return strings.Repeat(c, n), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,277 @@
+package deck
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "no includes",
+			files: map[string]string{"deck.md": "# One\n----\n# Two"},
+			want:  "# One\n----\n# Two\n",
+		},
+		{
+			name: "include relative to including file",
+			files: map[string]string{
+				"deck.md":            "# One\n----\n@include chapters/setup.md\n----\n# Three\n",
+				"chapters/setup.md":  "# Two\n@include ../shared/footer.md\n",
+				"shared/footer.md":   "footer",
+				"chapters/unused.md": "unused",
+			},
+			want: "# One\n----\n# Two\nfooter\n----\n# Three\n",
+		},
+		{
+			name: "quoted path",
+			files: map[string]string{
+				"deck.md":    "@include \"my file.md\"\n",
+				"my file.md": "# Quoted\n",
+			},
+			want: "# Quoted\n",
+		},
+		{
+			name: "directive inside code block is kept",
+			files: map[string]string{
+				"deck.md": "```md\n@include other.md\n```\n",
+			},
+			want: "```md\n@include other.md\n```\n",
+		},
+		{
+			name: "similar prefix is not a directive",
+			files: map[string]string{
+				"deck.md": "@included works\n",
+			},
+			want: "@included works\n",
+		},
+		{
+			name: "missing include",
+			files: map[string]string{
+				"deck.md": "# One\n\n@include missing.md\n",
+			},
+			wantErr: "deck.md:3: include",
+		},
+		{
+			name: "include cycle",
+			files: map[string]string{
+				"deck.md": "@include a.md\n",
+				"a.md":    "# A\n@include b.md\n",
+				"b.md":    "@include a.md\n",
+			},
+			wantErr: "b.md:1: include cycle",
+		},
+		{
+			name: "empty directive",
+			files: map[string]string{
+				"deck.md": "@include\n",
+			},
+			wantErr: "deck.md:1: @include requires a path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+
+			src, err := Load(filepath.Join(dir, "deck.md"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error = %v", err)
+			}
+
+			if src.Text != tt.want {
+				t.Errorf("Load() text = %q, want %q", src.Text, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_CycleError(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"deck.md": "@include deck.md\n"})
+
+	_, err := Load(filepath.Join(dir, "deck.md"))
+	if !errors.Is(err, ErrIncludeCycle) {
+		t.Errorf("Load() error = %v, want %v", err, ErrIncludeCycle)
+	}
+}
+
+func TestLoad_Files(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"deck.md": "@include a.md\n@include b.md\n@include a.md\n",
+		"a.md":    "a",
+		"b.md":    "b",
+	})
+
+	src, err := Load(filepath.Join(dir, "deck.md"))
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "deck.md"),
+		filepath.Join(dir, "a.md"),
+		filepath.Join(dir, "b.md"),
+	}
+	if strings.Join(src.Files, ",") != strings.Join(want, ",") {
+		t.Errorf("Files = %v, want %v", src.Files, want)
+	}
+}
+
+func TestSource_Slides(t *testing.T) {
+	fsys := fstest.MapFS{
+		"deck.md":       {Data: []byte("# One\n----\n@include parts/part.md\n----\n# Four\n")},
+		"parts/part.md": {Data: []byte("---\ntitle: Two\n---\n# Two\n----\n# Three\n---\n")},
+	}
+
+	src, err := LoadFS(fsys, "deck.md")
+	if err != nil {
+		t.Fatalf("LoadFS() unexpected error = %v", err)
+	}
+
+	want := []Slide{
+		{Body: "# One\n", Position: Position{File: "deck.md", Line: 1}, Dir: "."},
+		{
+			Body:       "# Two\n",
+			Properties: "title: Two\n",
+			Position:   Position{File: "parts/part.md", Line: 1},
+			Dir:        "parts",
+		},
+		{Body: "# Three\n---\n", Position: Position{File: "parts/part.md", Line: 6}, Dir: "parts"},
+		{Body: "# Four\n", Position: Position{File: "deck.md", Line: 5}, Dir: "."},
+	}
+
+	got := src.Slides()
+	if len(got) != len(want) {
+		t.Fatalf("Slides() returned %d slides, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Slides()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		name           string
+		in             string
+		wantBody       string
+		wantProperties string
+	}{
+		{
+			name:     "no front matter",
+			in:       "# Title\n",
+			wantBody: "# Title\n",
+		},
+		{
+			name:           "front matter",
+			in:             "---\ntitle: x\n---\n# Title\n",
+			wantBody:       "# Title\n",
+			wantProperties: "title: x\n",
+		},
+		{
+			name:           "thematic break in body",
+			in:             "---\ntitle: x\n---\n# Title\n---\nmore\n",
+			wantBody:       "# Title\n---\nmore\n",
+			wantProperties: "title: x\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, properties := splitFrontMatter(tt.in)
+			if body != tt.wantBody {
+				t.Errorf("splitFrontMatter() body = %q, want %q", body, tt.wantBody)
+			}
+			if properties != tt.wantProperties {
+				t.Errorf("splitFrontMatter() properties = %q, want %q", properties, tt.wantProperties)
+			}
+		})
+	}
+}
+
+func TestSource_DeckHeader(t *testing.T) {
+	tests := []struct {
+		name        string
+		deck        string
+		wantHeaders []string
+		wantBodies  []string
+	}{
+		{
+			name:        "header only block",
+			deck:        "---\ndeck: true\ntitle: Talk\n---\n----\n# One\n",
+			wantHeaders: []string{"deck: true\ntitle: Talk\n"},
+			wantBodies:  []string{"# One\n"},
+		},
+		{
+			name:        "header followed by content",
+			deck:        "---\ndeck: true\ntitle: Talk\n---\n# One\n----\n# Two\n",
+			wantHeaders: []string{"deck: true\ntitle: Talk\n"},
+			wantBodies:  []string{"# One\n", "# Two\n"},
+		},
+		{
+			name:       "regular front matter",
+			deck:       "---\ntitle: One\n---\n# One\n",
+			wantBodies: []string{"# One\n"},
+		},
+		{
+			name:       "only the first block can be a header",
+			deck:       "# One\n----\n---\ndeck: true\n---\n# Two\n",
+			wantBodies: []string{"# One\n", "# Two\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := LoadFS(fstest.MapFS{"deck.md": {Data: []byte(tt.deck)}}, "deck.md")
+			if err != nil {
+				t.Fatalf("LoadFS() unexpected error = %v", err)
+			}
+
+			if strings.Join(src.Headers, "|") != strings.Join(tt.wantHeaders, "|") {
+				t.Errorf("Headers = %q, want %q", src.Headers, tt.wantHeaders)
+			}
+
+			var bodies []string
+			for _, s := range src.Slides() {
+				bodies = append(bodies, s.Body)
+				if len(tt.wantHeaders) > 0 && strings.Contains(s.Properties, "deck: true") {
+					t.Errorf("slide %q kept the deck header as properties", s.Body)
+				}
+			}
+			if strings.Join(bodies, "|") != strings.Join(tt.wantBodies, "|") {
+				t.Errorf("bodies = %q, want %q", bodies, tt.wantBodies)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package deck
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// ManifestName is the name of the optional file configuring a directory deck.
+const ManifestName = "deck.yaml"
+
+// manifest is the part of a directory deck's manifest the loader cares about.
+// Every other key is deck metadata or defaults and is passed on untouched in
+// [Source.Headers].
+type manifest struct {
+	// Order lists the markdown files of the deck, relative to the directory.
+	// When set, files not listed are left out of the deck.
+	Order []string `yaml:"order"`
+}
+
+// LoadDir reads a deck made of every markdown file in dir, in natural sort
+// order (so that 2-setup.md comes before 10-demo.md), each file holding one or
+// more slides. An optional deck.yaml manifest can define the order of the
+// files, deck metadata and defaults.
+func LoadDir(dir string) (*Source, error) {
+	files, header, err := dirFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no markdown files found in %s", dir)
+	}
+
+	l := newOSLoader()
+	src, err := l.load(files...)
+	if err != nil {
+		return nil, err
+	}
+
+	if header != "" {
+		src.Headers = append([]string{header}, src.Headers...)
+	}
+	src.Dirs = append(src.Dirs, dir)
+	src.Files = append(src.Files, filepath.Join(dir, ManifestName))
+
+	return src, nil
+}
+
+// dirFiles returns the paths of the markdown files making up the deck in dir,
+// along with the contents of its manifest.
+func dirFiles(dir string) ([]string, string, error) {
+	manifestPath := filepath.Join(dir, ManifestName)
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, "", err
+	}
+
+	var m manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", manifestPath, err)
+	}
+
+	if len(m.Order) > 0 {
+		files := make([]string, 0, len(m.Order))
+		for _, name := range m.Order {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err != nil {
+				return nil, "", fmt.Errorf("%s: %w", manifestPath, err)
+			}
+			files = append(files, path)
+		}
+		return files, string(data), nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".md" {
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	slices.SortFunc(names, naturalCompare)
+
+	files := make([]string, len(names))
+	for i, name := range names {
+		files[i] = filepath.Join(dir, name)
+	}
+
+	return files, string(data), nil
+}
+
+// naturalCompare compares a and b treating runs of digits as numbers, so that
+// "2-intro.md" sorts before "10-outro.md".
+func naturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		ca, cb := a[0], b[0]
+
+		if isDigit(ca) && isDigit(cb) {
+			na, restA := splitDigits(a)
+			nb, restB := splitDigits(b)
+
+			// Compare numerically: ignore leading zeros, then longer is larger.
+			ta, tb := strings.TrimLeft(na, "0"), strings.TrimLeft(nb, "0")
+			if len(ta) != len(tb) {
+				return len(ta) - len(tb)
+			}
+			if c := strings.Compare(ta, tb); c != 0 {
+				return c
+			}
+			if len(na) != len(nb) {
+				return len(na) - len(nb)
+			}
+
+			a, b = restA, restB
+			continue
+		}
+
+		if ca != cb {
+			return int(ca) - int(cb)
+		}
+		a, b = a[1:], b[1:]
+	}
+
+	return len(a) - len(b)
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func splitDigits(s string) (digits, rest string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
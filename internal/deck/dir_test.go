@@ -0,0 +1,140 @@
+package deck
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNaturalCompare(t *testing.T) {
+	names := []string{
+		"10-outro.md",
+		"2-setup.md",
+		"01-intro.md",
+		"b.md",
+		"a.md",
+		"2-demo.md",
+		"002-setup.md",
+	}
+	want := []string{
+		"01-intro.md",
+		"2-demo.md",
+		"2-setup.md",
+		"002-setup.md",
+		"10-outro.md",
+		"a.md",
+		"b.md",
+	}
+
+	slices.SortFunc(names, naturalCompare)
+	if !slices.Equal(names, want) {
+		t.Errorf("sorted = %v, want %v", names, want)
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		want       string
+		wantHeader string
+		wantErr    bool
+	}{
+		{
+			name: "natural order",
+			files: map[string]string{
+				"10-end.md":   "# End\n",
+				"2-middle.md": "# Middle\n",
+				"1-intro.md":  "# Intro\n----\n# Intro 2\n",
+				"notes.txt":   "ignored",
+			},
+			want: "# Intro\n----\n# Intro 2\n----\n# Middle\n----\n# End\n",
+		},
+		{
+			name: "manifest order",
+			files: map[string]string{
+				"deck.yaml": "title: Talk\norder:\n  - b.md\n  - a.md\n",
+				"a.md":      "# A\n",
+				"b.md":      "# B\n",
+				"c.md":      "# C\n",
+			},
+			want:       "# B\n----\n# A\n",
+			wantHeader: "title: Talk\norder:\n  - b.md\n  - a.md\n",
+		},
+		{
+			name: "manifest without order",
+			files: map[string]string{
+				"deck.yaml": "author: Someone\n",
+				"b.md":      "# B\n",
+				"a.md":      "# A\n",
+			},
+			want:       "# A\n----\n# B\n",
+			wantHeader: "author: Someone\n",
+		},
+		{
+			name: "missing file in manifest",
+			files: map[string]string{
+				"deck.yaml": "order: [missing.md]\n",
+				"a.md":      "# A\n",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "empty directory",
+			files:   map[string]string{"notes.txt": "nothing"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+
+			src, err := Load(dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if src.Text != tt.want {
+				t.Errorf("Load() text = %q, want %q", src.Text, tt.want)
+			}
+			if tt.wantHeader != "" && !slices.Equal(src.Headers, []string{tt.wantHeader}) {
+				t.Errorf("Load() headers = %q, want %q", src.Headers, tt.wantHeader)
+			}
+			if tt.wantHeader == "" && len(src.Headers) != 0 {
+				t.Errorf("Load() headers = %q, want none", src.Headers)
+			}
+			if !slices.Contains(src.Files, filepath.Join(dir, ManifestName)) {
+				t.Errorf("Load() files = %v, want manifest included", src.Files)
+			}
+		})
+	}
+}
+
+func TestLoadDir_Positions(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"1.md": "# One\n----\n# Two\n",
+		"2.md": "\n# Three\n",
+	})
+
+	src, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	want := []string{"1.md:1", "1.md:3", "2.md:1"}
+	var got []string
+	for _, s := range src.Slides() {
+		got = append(got, strings.TrimPrefix(s.Position.String(), dir+string(filepath.Separator)))
+		if s.Dir != dir {
+			t.Errorf("Dir of %s = %q, want %q", s.Position, s.Dir, dir)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("positions = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,159 @@
+package deck
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateFuncs are the functions available to slide templates on top of the
+// text/template builtins.
+var templateFuncs = template.FuncMap{
+	"env": os.Getenv,
+	"date": func(layout string) string {
+		return time.Now().Format(layout)
+	},
+}
+
+// Expand executes the template actions of a slide body with data. Fenced code
+// blocks and inline code spans are left untouched so that code samples can
+// contain `{{`, which prose can spell {{ "{{" }}. Referencing
+// a map key that does not exist, such as an undefined variable, is an error.
+// Line numbers in errors are relative to the start of body.
+func Expand(body string, data any) (string, error) {
+	if !strings.Contains(body, "{{") {
+		return body, nil
+	}
+
+	var (
+		out     strings.Builder
+		segment strings.Builder
+		fence   string
+		start   int
+	)
+
+	flush := func() error {
+		if segment.Len() == 0 {
+			return nil
+		}
+		defer segment.Reset()
+
+		// Pad the segment with a comment so that line numbers in errors match
+		// the body without changing the output
+		text := quoteCodeSpans(segment.String())
+		if start > 0 {
+			text = "{{/*" + strings.Repeat("\n", start) + "*/}}" + text
+		}
+
+		tmpl, err := template.New("slide").
+			Option("missingkey=error").
+			Funcs(templateFuncs).
+			Parse(text)
+		if err != nil {
+			return err
+		}
+
+		return tmpl.Execute(&out, data)
+	}
+
+	lines := strings.SplitAfter(body, "\n")
+	for i, line := range lines {
+		marker, isFence := fenceMarker(strings.TrimRight(line, "\n"))
+
+		switch {
+		case isFence && fence == "":
+			if err := flush(); err != nil {
+				return "", err
+			}
+			fence = marker
+			out.WriteString(line)
+		case fence != "":
+			if isFence && strings.HasPrefix(marker, fence) {
+				fence = ""
+				start = i + 1
+			}
+			out.WriteString(line)
+		default:
+			segment.WriteString(line)
+		}
+	}
+
+	if err := flush(); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// quoteCodeSpans replaces the inline code spans of text containing `{{` with
+// actions printing them as they are. Comments keep the lines of the spans
+// running over several, for line numbers in errors to match text.
+func quoteCodeSpans(text string) string {
+	if !strings.Contains(text, "`") {
+		return text
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		switch {
+		// Backslash escaped backticks open no span
+		case text[i] == '\\' && i+1 < len(text) && text[i+1] == '`':
+			b.WriteString(text[i : i+2])
+			i += 2
+			continue
+		case text[i] != '`':
+			b.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		ticks := backticks(text[i:])
+		end := closingBackticks(text, i+ticks, ticks)
+		if end < 0 {
+			// Unclosed backticks are literal
+			b.WriteString(text[i : i+ticks])
+			i += ticks
+			continue
+		}
+
+		span := text[i : end+ticks]
+		if strings.Contains(span, "{{") {
+			b.WriteString("{{" + strconv.Quote(span) + "}}")
+			if lines := strings.Count(span, "\n"); lines > 0 {
+				b.WriteString("{{/*" + strings.Repeat("\n", lines) + "*/}}")
+			}
+		} else {
+			b.WriteString(span)
+		}
+		i = end + ticks
+	}
+	return b.String()
+}
+
+// backticks returns the length of the run of backticks s starts with.
+func backticks(s string) int {
+	n := 0
+	for n < len(s) && s[n] == '`' {
+		n++
+	}
+	return n
+}
+
+// closingBackticks returns the index of the first run of exactly n backticks
+// in text from start, which closes a code span opened with n, or -1.
+func closingBackticks(text string, start, n int) int {
+	for i := start; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		run := backticks(text[i:])
+		if run == n {
+			return i
+		}
+		i += run
+	}
+	return -1
+}
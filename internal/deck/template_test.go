@@ -0,0 +1,102 @@
+package deck
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpand(t *testing.T) {
+	t.Setenv("KYMA_TEST_USER", "gopher")
+
+	data := struct {
+		SlideNumber int
+		Total       int
+		Vars        map[string]any
+	}{
+		SlideNumber: 2,
+		Total:       5,
+		Vars:        map[string]any{"repo": "museslabs/kyma"},
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "no actions",
+			body: "# Title\n",
+			want: "# Title\n",
+		},
+		{
+			name: "fields and vars",
+			body: "# {{ .SlideNumber }}/{{ .Total }}\n\n{{ .Vars.repo }}\n",
+			want: "# 2/5\n\nmuseslabs/kyma\n",
+		},
+		{
+			name: "env",
+			body: `Hi {{ env "KYMA_TEST_USER" }}`,
+			want: "Hi gopher",
+		},
+		{
+			name: "date",
+			body: `{{ date "2006" }}`,
+			want: time.Now().Format("2006"),
+		},
+		{
+			name: "code blocks are kept",
+			body: "{{ .SlideNumber }}\n```go\nfmt.Println(\"{{ .Missing }}\")\n```\n{{ .Total }}\n",
+			want: "2\n```go\nfmt.Println(\"{{ .Missing }}\")\n```\n5\n",
+		},
+		{
+			name: "inline code is kept",
+			body: "Use `{{ .x }}` or ``{{ `y` }}``, {{ .Total }} times\n",
+			want: "Use `{{ .x }}` or ``{{ `y` }}``, 5 times\n",
+		},
+		{
+			name: "escaped braces",
+			body: "Literal {{ \"{{\" }} and \\`{{ .Total }}\n",
+			want: "Literal {{ and \\`5\n",
+		},
+		{
+			name:    "line numbers after inline code over lines",
+			body:    "`{{\n}}`\n\n{{ .Vars.missing }}\n",
+			wantErr: `slide:4:`,
+		},
+		{
+			name:    "undefined variable",
+			body:    "# Title\n\n{{ .Vars.missing }}\n",
+			wantErr: `slide:3:`,
+		},
+		{
+			name:    "line numbers after a code block",
+			body:    "```\ncode\n```\n\n{{ .Vars.missing }}\n",
+			wantErr: `slide:5:`,
+		},
+		{
+			name:    "unknown field",
+			body:    "{{ .Author }}",
+			wantErr: "can't evaluate field Author",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Expand(tt.body, data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expand() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expand() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package deck
+
+import "strings"
+
+// TOC returns the markdown of an agenda slide listing sections after body,
+// with the current section highlighted. An empty body gets a default "Agenda"
+// heading.
+func TOC(body string, sections []string, current string) string {
+	var b strings.Builder
+
+	if strings.TrimSpace(body) == "" {
+		b.WriteString("# Agenda\n")
+	} else {
+		b.WriteString(strings.TrimRight(body, "\n"))
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+
+	for _, section := range sections {
+		if section == current {
+			b.WriteString("- **" + section + "** ◀\n")
+			continue
+		}
+		b.WriteString("- " + section + "\n")
+	}
+
+	return b.String()
+}
+
+// Sections returns the distinct non-empty names in sections, in order of first
+// appearance.
+func Sections(sections []string) []string {
+	var out []string
+	seen := map[string]struct{}{}
+
+	for _, s := range sections {
+		if s == "" {
+			continue
+		}
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+
+	return out
+}
@@ -0,0 +1,33 @@
+package deck
+
+import "testing"
+
+func TestTOC(t *testing.T) {
+	sections := []string{"Intro", "Internals", "Outro"}
+
+	got := TOC("", sections, "Internals")
+	want := "# Agenda\n\n- Intro\n- **Internals** ◀\n- Outro\n"
+	if got != want {
+		t.Errorf("TOC() = %q, want %q", got, want)
+	}
+
+	got = TOC("# Today\n\n", sections, "")
+	want = "# Today\n\n- Intro\n- Internals\n- Outro\n"
+	if got != want {
+		t.Errorf("TOC() with body = %q, want %q", got, want)
+	}
+}
+
+func TestSections(t *testing.T) {
+	got := Sections([]string{"", "Intro", "Intro", "Internals", "", "Intro", "Outro"})
+	want := []string{"Intro", "Internals", "Outro"}
+
+	if len(got) != len(want) {
+		t.Fatalf("Sections() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Sections() = %v, want %v", got, want)
+		}
+	}
+}
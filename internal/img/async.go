@@ -0,0 +1,167 @@
+package img
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrPending is returned by the backends of [Get] while an image is drawn in
+// the background, until it is ready and its path sent on [Loaded].
+var ErrPending = errors.New("image is still loading")
+
+// Prefetcher is implemented by backends drawing images in the background,
+// which can start drawing an image before it is shown.
+type Prefetcher interface {
+	Prefetch(path string, width, height int)
+}
+
+var (
+	async atomic.Bool
+	// loaded receives the paths of the images drawn in the background. It is
+	// buffered so drawing never waits on the UI, and a full buffer already
+	// holds a redraw.
+	loaded = make(chan string, 64)
+)
+
+// SetAsync sets whether images are drawn in background goroutines rather
+// than when rendered, which only a UI listening on [Loaded] can wait for.
+func SetAsync(enabled bool) {
+	async.Store(enabled)
+}
+
+// Loaded returns the channel the paths of the images drawn in the background
+// are sent on once they are ready.
+func Loaded() <-chan string {
+	return loaded
+}
+
+// cachedBackend is implemented by the backends keeping their drawings in the
+// shared cache, which can be looked up there without drawing, concurrently
+// with drawing.
+type cachedBackend interface {
+	ImageBackend
+	cached(path string, width, height int, symbols bool) (string, bool)
+}
+
+type renderKey struct {
+	path          string
+	width, height int
+	symbols       bool
+}
+
+// asyncBackend draws the images of backend in background goroutines when
+// [SetAsync] enables it, one at a time since drawing is not safe for
+// concurrent use, and finds them in the cache once drawn, which is.
+type asyncBackend struct {
+	backend cachedBackend
+	// work serializes the calls to backend.
+	work sync.Mutex
+
+	mu      sync.Mutex
+	pending map[renderKey]bool
+	// failed holds the errors of the images that could not be drawn, for
+	// them not to be drawn again on every render.
+	failed map[renderKey]error
+	// generation is bumped by Evict for the errors of the drawings in
+	// flight to be dropped.
+	generation int
+}
+
+func newAsyncBackend(backend cachedBackend) *asyncBackend {
+	return &asyncBackend{
+		backend: backend,
+		pending: map[renderKey]bool{},
+		failed:  map[renderKey]error{},
+	}
+}
+
+func (b *asyncBackend) SymbolsOnly() bool {
+	return b.backend.SymbolsOnly()
+}
+
+func (b *asyncBackend) Evict(path string) {
+	b.mu.Lock()
+	b.generation++
+	for k := range b.failed {
+		if k.path == path {
+			delete(b.failed, k)
+		}
+	}
+	b.mu.Unlock()
+
+	b.backend.Evict(path)
+}
+
+// Render returns the image drawn, or [ErrPending] when drawing it has only
+// started in the background.
+func (b *asyncBackend) Render(path string, width, height int, symbols bool) (string, error) {
+	if !async.Load() {
+		b.work.Lock()
+		defer b.work.Unlock()
+		return b.backend.Render(path, width, height, symbols)
+	}
+
+	key := renderKey{path: path, width: width, height: height, symbols: symbols}
+	if out, ok := b.backend.cached(path, width, height, symbols); ok {
+		return out, nil
+	}
+	if err := b.failure(key); err != nil {
+		return "", err
+	}
+	b.start(key)
+	return "", ErrPending
+}
+
+// Prefetch starts drawing the image at path in the background, both in
+// symbols and in pixels, unless images are drawn when rendered.
+func (b *asyncBackend) Prefetch(path string, width, height int) {
+	if !async.Load() {
+		return
+	}
+
+	for _, symbols := range []bool{true, false} {
+		if !symbols && b.SymbolsOnly() {
+			continue
+		}
+		key := renderKey{path: path, width: width, height: height, symbols: symbols}
+		if _, ok := b.backend.cached(path, width, height, symbols); !ok && b.failure(key) == nil {
+			b.start(key)
+		}
+	}
+}
+
+func (b *asyncBackend) failure(key renderKey) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failed[key]
+}
+
+// start draws the image of key in a goroutine, unless it already is.
+func (b *asyncBackend) start(key renderKey) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pending[key] {
+		return
+	}
+	b.pending[key] = true
+	generation := b.generation
+
+	go func() {
+		b.work.Lock()
+		_, err := b.backend.Render(key.path, key.width, key.height, key.symbols)
+		b.work.Unlock()
+
+		b.mu.Lock()
+		delete(b.pending, key)
+		if err != nil && generation == b.generation {
+			b.failed[key] = err
+		}
+		b.mu.Unlock()
+
+		select {
+		case loaded <- key.path:
+		default:
+		}
+	}()
+}
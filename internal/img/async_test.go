@@ -0,0 +1,86 @@
+package img
+
+import (
+	"errors"
+	"image"
+	"testing"
+	"time"
+)
+
+func TestAsyncBackend_Render(t *testing.T) {
+	SetAsync(true)
+	t.Cleanup(func() { SetAsync(false) })
+
+	path := writePNG(t, image.NewNRGBA(image.Rect(0, 0, 4, 2)))
+	b := newAsyncBackend(NewNativeBackend())
+
+	if _, err := b.Render(path, 4, 10, true); !errors.Is(err, ErrPending) {
+		t.Fatalf("Render() error = %v, want %v", err, ErrPending)
+	}
+	waitLoaded(t, path)
+
+	want, err := NewNativeBackend().Render(path, 4, 10, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := b.Render(path, 4, 10, true); err != nil || got != want {
+		t.Errorf("Render() = %q, %v, want %q", got, err, want)
+	}
+
+	// Evicted images are drawn again
+	b.Evict(path)
+	if _, err := b.Render(path, 4, 10, true); !errors.Is(err, ErrPending) {
+		t.Errorf("Render() after Evict() error = %v, want %v", err, ErrPending)
+	}
+	waitLoaded(t, path)
+}
+
+func TestAsyncBackend_Prefetch(t *testing.T) {
+	SetAsync(true)
+	t.Cleanup(func() { SetAsync(false) })
+
+	path := writePNG(t, image.NewNRGBA(image.Rect(0, 0, 4, 2)))
+	b := newAsyncBackend(NewNativeBackend())
+
+	b.Prefetch(path, 4, 10)
+	waitLoaded(t, path)
+
+	if _, err := b.Render(path, 4, 10, true); err != nil {
+		t.Errorf("Render() after Prefetch() error = %v", err)
+	}
+}
+
+func TestAsyncBackend_Sync(t *testing.T) {
+	path := writePNG(t, image.NewNRGBA(image.Rect(0, 0, 4, 2)))
+	b := newAsyncBackend(NewNativeBackend())
+
+	if out, err := b.Render(path, 4, 10, true); err != nil || out == "" {
+		t.Errorf("Render() = %q, %v, want the image drawn right away", out, err)
+	}
+}
+
+// waitLoaded waits for the image at path to be drawn in the background.
+func waitLoaded(t *testing.T, path string) {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case p := <-Loaded():
+			if p == path {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("%s was not loaded", path)
+		}
+	}
+}
+
+func TestGet_Shared(t *testing.T) {
+	if Get("native") != Get("native") {
+		t.Error("Get() returned a backend of its own, want the one shared by every slide")
+	}
+	if Get("native") == Get("docs") {
+		t.Error("Get() returned the same backend for different names")
+	}
+}
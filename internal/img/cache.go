@@ -0,0 +1,292 @@
+package img
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheBudget is the memory the drawings of images are kept in by
+// default, in bytes.
+const DefaultCacheBudget = 64 << 20
+
+// DefaultDiskCacheBudget is the disk space the drawings of images are kept
+// in by default when written to a directory, in bytes.
+const DefaultDiskCacheBudget = 512 << 20
+
+// cacheKey identifies a drawing of an image: the file drawn, as it was when
+// drawn, the size and mode it was drawn in, and by what for which terminal.
+type cacheKey struct {
+	backend       string
+	path          string
+	modTime       time.Time
+	size          int64
+	width, height int
+	symbols       bool
+	// capabilities describes the terminal the image was drawn for, as far
+	// as it changes the drawing.
+	capabilities string
+}
+
+func newCacheKey(backend, path string, info fs.FileInfo, width, height int, symbols bool, capabilities string) cacheKey {
+	return cacheKey{
+		backend:      backend,
+		path:         path,
+		modTime:      info.ModTime(),
+		size:         info.Size(),
+		width:        width,
+		height:       height,
+		symbols:      symbols,
+		capabilities: capabilities,
+	}
+}
+
+// bytes returns about how much memory a drawing under the key takes.
+func (k cacheKey) bytes(out string) int {
+	const overhead = 128
+	return len(out) + len(k.backend) + len(k.path) + len(k.capabilities) + overhead
+}
+
+// file returns the name of the drawing under the key in the disk cache.
+func (k cacheKey) file() string {
+	path, err := filepath.Abs(k.path)
+	if err != nil {
+		path = k.path
+	}
+	sum := sha256.Sum256(fmt.Appendf(
+		nil,
+		"%q %q %d %d %d %d %t %q",
+		k.backend,
+		path,
+		k.modTime.UnixNano(),
+		k.size,
+		k.width,
+		k.height,
+		k.symbols,
+		k.capabilities,
+	))
+	return hex.EncodeToString(sum[:])
+}
+
+type cacheEntry struct {
+	key cacheKey
+	out string
+}
+
+// imageCache holds the drawings of images for every backend, dropping the
+// least recently used once they take more memory than its budget. Drawings
+// are also written to a directory when it has one, for them to outlive the
+// process. It is safe for concurrent use.
+type imageCache struct {
+	mu      sync.Mutex
+	budget  int
+	used    int
+	entries map[cacheKey]*list.Element
+	// recent holds the entries from the most recently used to the least.
+	recent *list.List
+	dir    string
+}
+
+func newImageCache(budget int) *imageCache {
+	return &imageCache{
+		budget:  budget,
+		entries: map[cacheKey]*list.Element{},
+		recent:  list.New(),
+	}
+}
+
+// cache is the cache shared by every backend.
+var cache = newImageCache(DefaultCacheBudget)
+
+// SetCacheBudget sets how much memory, in bytes, the drawings of images are
+// kept in.
+func SetCacheBudget(budget int) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.budget = budget
+	cache.shrink()
+}
+
+// SetCacheDir sets the directory drawings of images are written to, for
+// them to be read back rather than drawn again by later runs. An empty dir
+// keeps them in memory only. The drawings least recently used are removed in
+// the background until those left take at most budget bytes, the drawings
+// of this run adding to them.
+func SetCacheDir(dir string, budget int64) error {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		go func() {
+			if err := pruneCacheDir(dir, budget); err != nil {
+				slog.Warn("Failed to prune the image disk cache", "error", err, "dir", dir)
+			}
+		}()
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.dir = dir
+	return nil
+}
+
+// staleTemp is how old temporary files are before they are taken for those
+// of runs that stopped writing them.
+const staleTemp = time.Hour
+
+// pruneCacheDir removes the drawings of dir least recently used, which have
+// the oldest modification time, until those left take at most budget bytes.
+// Temporary files left behind are removed too.
+func pruneCacheDir(dir string, budget int64) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var files []fs.FileInfo
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if strings.HasPrefix(info.Name(), ".tmp-") {
+			if time.Since(info.ModTime()) > staleTemp {
+				os.Remove(filepath.Join(dir, info.Name()))
+			}
+			continue
+		}
+		files = append(files, info)
+	}
+
+	slices.SortFunc(files, func(a, b fs.FileInfo) int {
+		return b.ModTime().Compare(a.ModTime())
+	})
+	var used int64
+	for _, info := range files {
+		used += info.Size()
+		if used <= budget {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, info.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get returns the drawing under key, from memory or else from disk.
+func (c *imageCache) Get(key cacheKey) (string, bool) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.recent.MoveToFront(e)
+		c.mu.Unlock()
+		return e.Value.(*cacheEntry).out, true
+	}
+	dir := c.dir
+	c.mu.Unlock()
+
+	if dir == "" || key.modTime.IsZero() {
+		return "", false
+	}
+	path := filepath.Join(dir, key.file())
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	// The drawings read back are the last to be pruned
+	now := time.Now()
+	os.Chtimes(path, now, now)
+
+	out := string(data)
+	c.mu.Lock()
+	c.add(key, out)
+	c.mu.Unlock()
+	return out, true
+}
+
+// Put stores out as the drawing under key.
+func (c *imageCache) Put(key cacheKey, out string) {
+	c.mu.Lock()
+	c.add(key, out)
+	dir := c.dir
+	c.mu.Unlock()
+
+	// Files without a modification time, like the embedded documentation,
+	// could change without the key changing
+	if dir == "" || key.modTime.IsZero() {
+		return
+	}
+	if err := writeCacheFile(filepath.Join(dir, key.file()), out); err != nil {
+		slog.Warn("Failed to write image to the disk cache", "error", err, "path", key.path)
+	}
+}
+
+// Evict drops every drawing of the image at path from memory, whatever
+// drew it and however.
+func (c *imageCache) Evict(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, e := range c.entries {
+		if key.path == path {
+			c.remove(e)
+		}
+	}
+}
+
+// add stores out under key in memory. c.mu must be held.
+func (c *imageCache) add(key cacheKey, out string) {
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+	// Drawings bigger than the whole budget would only push the others out
+	if key.bytes(out) > c.budget {
+		return
+	}
+
+	c.entries[key] = c.recent.PushFront(&cacheEntry{key: key, out: out})
+	c.used += key.bytes(out)
+	c.shrink()
+}
+
+// shrink drops the least recently used drawings until they fit in the
+// budget. c.mu must be held.
+func (c *imageCache) shrink() {
+	for c.used > c.budget && c.recent.Len() > 0 {
+		c.remove(c.recent.Back())
+	}
+}
+
+func (c *imageCache) remove(e *list.Element) {
+	entry := c.recent.Remove(e).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.used -= entry.key.bytes(entry.out)
+	forgetImage(entry.key.kittyID())
+}
+
+// writeCacheFile writes out to path through a temporary file, for runs
+// reading it concurrently never to see it half written.
+func writeCacheFile(path, out string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(out); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,156 @@
+package img
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testKey(path string) cacheKey {
+	return cacheKey{backend: "test", path: path, modTime: time.Unix(1, 0), size: 1, width: 10, height: 5}
+}
+
+func TestImageCache_LRU(t *testing.T) {
+	a, b, c := testKey("a"), testKey("b"), testKey("c")
+	out := strings.Repeat("x", 100)
+
+	// Room for two drawings only
+	lru := newImageCache(2 * a.bytes(out))
+	lru.Put(a, out)
+	lru.Put(b, out)
+
+	// Using a makes b the least recently used, dropped for c
+	if _, ok := lru.Get(a); !ok {
+		t.Fatal("Get(a) missed")
+	}
+	lru.Put(c, out)
+
+	for key, want := range map[cacheKey]bool{a: true, b: false, c: true} {
+		if _, ok := lru.Get(key); ok != want {
+			t.Errorf("Get(%s) hit = %v, want %v", key.path, ok, want)
+		}
+	}
+	if lru.used > lru.budget {
+		t.Errorf("used = %d, over the budget of %d", lru.used, lru.budget)
+	}
+}
+
+func TestImageCache_Key(t *testing.T) {
+	lru := newImageCache(DefaultCacheBudget)
+	key := testKey("a")
+	lru.Put(key, "drawing")
+
+	// Drawings of the file before it changed, or in another mode, miss
+	changed := key
+	changed.modTime = time.Unix(2, 0)
+	pixels := key
+	pixels.symbols = true
+	for _, k := range []cacheKey{changed, pixels} {
+		if _, ok := lru.Get(k); ok {
+			t.Errorf("Get(%+v) hit", k)
+		}
+	}
+
+	placedMu.Lock()
+	placed[key.kittyID()] = true
+	placedMu.Unlock()
+	t.Cleanup(func() {
+		placedMu.Lock()
+		delete(placed, key.kittyID())
+		placedMu.Unlock()
+	})
+
+	lru.Evict("a")
+	if _, ok := lru.Get(key); ok {
+		t.Error("Get() hit after Evict()")
+	}
+	placedMu.Lock()
+	live := placed[key.kittyID()]
+	placedMu.Unlock()
+	if live {
+		t.Error("the kitty image of the drawing is still placed after Evict()")
+	}
+	if lru.used != 0 {
+		t.Errorf("used = %d after Evict(), want 0", lru.used)
+	}
+}
+
+func TestImageCache_Disk(t *testing.T) {
+	dir := t.TempDir()
+	key := testKey("a")
+
+	first := newImageCache(DefaultCacheBudget)
+	first.dir = dir
+	first.Put(key, "drawing")
+
+	// Another run reads the drawing back
+	second := newImageCache(DefaultCacheBudget)
+	second.dir = dir
+	if out, ok := second.Get(key); !ok || out != "drawing" {
+		t.Errorf("Get() = %q, %v, want the drawing from disk", out, ok)
+	}
+
+	// Files without a modification time are kept in memory only
+	embedded := testKey("b")
+	embedded.modTime = time.Time{}
+	first.Put(embedded, "drawing")
+	if _, err := os.Stat(filepath.Join(dir, embedded.file())); !os.IsNotExist(err) {
+		t.Errorf("drawing of a file without a modification time written to disk: %v", err)
+	}
+}
+
+func TestNativeBackend_SharedCache(t *testing.T) {
+	path := writePNG(t, image.NewNRGBA(image.Rect(0, 0, 4, 2)))
+
+	// A drawing by one backend is found by another
+	if _, err := NewNativeBackend().Render(path, 4, 10, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := NewNativeBackend().cached(path, 4, 10, true); !ok {
+		t.Error("cached() missed the drawing of another backend")
+	}
+
+	// A file changing is drawn again
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := NewNativeBackend().cached(path, 4, 10, true); ok {
+		t.Error("cached() hit after the file changed")
+	}
+}
+
+func TestPruneCacheDir(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	// Three drawings of 10 bytes, used from the oldest to the most recent,
+	// and a temporary file of a run that stopped
+	for i, name := range []string{"old", "used", "recent", ".tmp-1"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(strings.Repeat("x", 10)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		used := now.Add(time.Duration(i-3) * time.Hour)
+		if name == ".tmp-1" {
+			used = now.Add(-2 * staleTemp)
+		}
+		if err := os.Chtimes(path, used, used); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := pruneCacheDir(dir, 25); err != nil {
+		t.Fatalf("pruneCacheDir() error = %v", err)
+	}
+
+	for name, want := range map[string]bool{"old": false, "used": true, "recent": true, ".tmp-1": false} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if got := err == nil; got != want {
+			t.Errorf("%s kept = %v, want %v", name, got, want)
+		}
+	}
+}
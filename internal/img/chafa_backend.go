@@ -1,85 +1,94 @@
+//go:build !nochafa
+
 package img
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
-	"strconv"
-	"strings"
+	"sync"
 
 	"github.com/ploMP4/chafa-go"
 )
 
-type chafaCache struct {
-	cache map[string]string
-}
-
-func (c *chafaCache) key(path string, width, height int, symbols bool) string {
-	return strings.Join(
-		[]string{
-			path,
-			strconv.Itoa(int(width)),
-			strconv.Itoa(int(height)),
-			strconv.FormatBool(symbols),
-		},
-		"|",
-	)
-}
-
-func (c *chafaCache) Get(path string, width, height int, symbols bool) string {
-	img, ok := c.cache[c.key(path, width, height, symbols)]
-	if !ok {
-		return ""
-	}
-	return img
-}
-
-func (c *chafaCache) Save(img, path string, width, height int, symbols bool) {
-	c.cache[c.key(path, width, height, symbols)] = img
-}
-
 type chafaBackend struct {
-	cache *chafaCache
+	// fallback draws the images chafa fails to. Chafa failing to load
+	// panics as the program starts, before there is any backend to fall
+	// back from, which only builds with the nochafa tag avoid.
+	fallback *nativeBackend
 }
 
 const nChannels = 4
 
 func NewChafaBackend() *chafaBackend {
 	return &chafaBackend{
-		cache: &chafaCache{
-			cache: map[string]string{},
-		},
+		fallback: NewNativeBackend(),
 	}
 }
 
 func (b *chafaBackend) SymbolsOnly() bool {
-	return b.detectTerminal().pixelMode == chafa.CHAFA_PIXEL_MODE_SYMBOLS
+	capabilities, _, err := terminal.detect()
+	if err != nil {
+		return b.fallback.SymbolsOnly()
+	}
+	return capabilities.pixelMode == chafa.CHAFA_PIXEL_MODE_SYMBOLS
 }
 
-func (b *chafaBackend) Render(path string, width, height int, symbols bool) (string, error) {
-	var err error
+func (b *chafaBackend) Evict(path string) {
+	cache.Evict(path)
+}
+
+func (b *chafaBackend) Render(path string, width, height int, symbols bool) (out string, err error) {
+	capabilities, terminalKey, err := terminal.detect()
+	if err != nil {
+		return b.fallback.Render(path, width, height, symbols)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	key := newCacheKey("chafa", path, info, width, height, symbols, terminalKey)
+	if out, ok := cache.Get(key); ok {
+		return out, nil
+	}
 
 	defer func() {
 		if r := recover(); r != nil {
-			if recErr, ok := r.(error); ok {
-				err = recErr
-			} else {
-				err = fmt.Errorf("panic: %v", r)
-			}
+			err = panicError(r)
+		}
+		if err != nil {
+			slog.Warn("Chafa failed to render image, falling back to the native backend", "error", err, "path", path)
+			out, err = b.fallback.Render(path, width, height, symbols)
+		}
+		if err == nil {
+			cache.Put(key, out)
 		}
 	}()
 
-	c := b.cache.Get(path, width, height, symbols)
-	if c != "" {
-		return c, nil
-	}
+	return b.render(path, key.kittyID(), int32(width), int32(height), symbols, capabilities)
+}
 
-	out, err := b.render(path, int32(width), int32(height), symbols)
-	b.cache.Save(out, path, width, height, symbols)
+func (b *chafaBackend) cached(path string, width, height int, symbols bool) (string, bool) {
+	_, terminalKey, err := terminal.detect()
+	if err != nil {
+		return b.fallback.cached(path, width, height, symbols)
+	}
 
-	return out, err
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false
+	}
+	return cache.Get(newCacheKey("chafa", path, info, width, height, symbols, terminalKey))
 }
 
-func (b chafaBackend) render(path string, width, height int32, symbols bool) (string, error) {
+func (b chafaBackend) render(
+	path string,
+	id uint32,
+	width, height int32,
+	symbols bool,
+	capabilities chafaTerminalCapabilities,
+) (string, error) {
 	pixels, pixelWidth, pixelHeight, err := chafa.Load(path)
 	if err != nil {
 		return "", err
@@ -87,10 +96,6 @@ func (b chafaBackend) render(path string, width, height int32, symbols bool) (st
 
 	chafa.CalcCanvasGeometry(width, height, &width, &height, 1, true, false)
 
-	capabilities := b.detectTerminal()
-	defer chafa.SymbolMapUnref(capabilities.symbolMap)
-	defer chafa.TermInfoUnref(capabilities.termInfo)
-
 	config := chafa.CanvasConfigNew()
 	defer chafa.CanvasConfigUnref(config)
 
@@ -98,7 +103,7 @@ func (b chafaBackend) render(path string, width, height int32, symbols bool) (st
 	chafa.CanvasConfigSetGeometry(config, width, height)
 	chafa.CanvasConfigSetPassthrough(config, capabilities.passthrough)
 	chafa.CanvasConfigSetSymbolMap(config, capabilities.symbolMap)
-	chafa.CanvasConfigSetCellGeometry(config, 18, 36)
+	chafa.CanvasConfigSetCellGeometry(config, capabilities.cellWidth, capabilities.cellHeight)
 
 	if symbols {
 		chafa.CanvasConfigSetPixelMode(config, chafa.CHAFA_PIXEL_MODE_SYMBOLS)
@@ -119,6 +124,9 @@ func (b chafaBackend) render(path string, width, height int32, symbols bool) (st
 	)
 	printable := chafa.CanvasPrint(canvas, nil)
 
+	if capabilities.pixelMode == chafa.CHAFA_PIXEL_MODE_KITTY {
+		return tagKitty(printable.String(), id), nil
+	}
 	return printable.String(), nil
 }
 
@@ -128,13 +136,63 @@ type chafaTerminalCapabilities struct {
 	pixelMode   chafa.PixelMode
 	passthrough chafa.Passthrough
 	symbolMap   *chafa.SymbolMap
+	// cellWidth and cellHeight are the size of a cell in pixels.
+	cellWidth, cellHeight int32
 }
 
-func (b chafaBackend) detectTerminal() chafaTerminalCapabilities {
+// chafaTerminal holds the capabilities of the terminal, detected by chafa
+// once for every graphics setting rather than for every image drawn or
+// looked up. It is safe for concurrent use. Images are only drawn once it
+// detected the capabilities, detected again only after [SetGraphics], which
+// is called before anything is drawn.
+type chafaTerminal struct {
+	mu      sync.Mutex
+	setting graphicsSetting
+	// detected is whether capabilities, key and err hold the detection
+	// for setting.
+	detected     bool
+	capabilities chafaTerminalCapabilities
+	// key describes capabilities in the keys of the cache.
+	key string
+	err error
+}
+
+// terminal is shared by the backends drawing with chafa.
+var terminal chafaTerminal
+
+// detect returns the capabilities of the terminal under the current
+// graphics setting and their description in the keys of the cache, or the
+// error chafa panicked with detecting them. The capabilities detected under
+// earlier settings are kept, the drawings in progress possibly using them.
+func (t *chafaTerminal) detect() (chafaTerminalCapabilities, string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	setting := currentGraphics()
+	if !t.detected || t.setting != setting {
+		t.capabilities, t.err = detectTerminal(setting)
+		t.key = capabilitiesKey(t.capabilities)
+		t.setting, t.detected = setting, true
+		if t.err != nil {
+			slog.Warn("Chafa failed to detect the terminal, falling back to the native backend", "error", t.err)
+		}
+	}
+	return t.capabilities, t.key, t.err
+}
+
+// detectTerminal returns the capabilities of the terminal under setting, or
+// the error chafa panicked with.
+func detectTerminal(setting graphicsSetting) (capabilities chafaTerminalCapabilities, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r)
+		}
+	}()
+
 	termInfo := chafa.TermDbDetect(chafa.TermDbGetDefault(), os.Environ())
 
 	mode := chafa.TermInfoGetBestCanvasMode(termInfo)
-	pixelMode := chafa.TermInfoGetBestPixelMode(termInfo)
+	pixelMode := graphicsPixelMode(setting, chafa.TermInfoGetBestPixelMode(termInfo))
 
 	passthrough := chafa.CHAFA_PASSTHROUGH_NONE
 	if chafa.TermInfoGetIsPixelPassthroughNeeded(termInfo, pixelMode) {
@@ -150,5 +208,44 @@ func (b chafaBackend) detectTerminal() chafaTerminalCapabilities {
 		pixelMode:   pixelMode,
 		passthrough: passthrough,
 		symbolMap:   symbolMap,
+		cellWidth:   int32(setting.cellWidth),
+		cellHeight:  int32(setting.cellHeight),
+	}, nil
+}
+
+// capabilitiesKey describes capabilities, as far as they change drawings.
+func capabilitiesKey(capabilities chafaTerminalCapabilities) string {
+	return fmt.Sprintf(
+		"canvas=%d pixels=%d passthrough=%d cell=%dx%d",
+		capabilities.canvasMode,
+		capabilities.pixelMode,
+		capabilities.passthrough,
+		capabilities.cellWidth,
+		capabilities.cellHeight,
+	)
+}
+
+// panicError returns the value chafa panicked with as an error.
+func panicError(r any) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("panic: %v", r)
+}
+
+// graphicsPixelMode returns the pixel mode of the protocol setting asks
+// for, or detected when probing is asked for, chafa knowing more terminals.
+func graphicsPixelMode(setting graphicsSetting, detected chafa.PixelMode) chafa.PixelMode {
+	switch setting.requested {
+	case GraphicsKitty:
+		return chafa.CHAFA_PIXEL_MODE_KITTY
+	case GraphicsSixel:
+		return chafa.CHAFA_PIXEL_MODE_SIXELS
+	case GraphicsITerm2:
+		return chafa.CHAFA_PIXEL_MODE_ITERM2
+	case GraphicsSymbols:
+		return chafa.CHAFA_PIXEL_MODE_SYMBOLS
+	default:
+		return detected
 	}
 }
@@ -1,12 +1,14 @@
+//go:build !nochafa
+
 package img
 
 import (
-	"fmt"
 	"image"
 	"image/draw"
 	"image/jpeg"
 	"image/png"
-	"os"
+	"io/fs"
+	"log/slog"
 	"path/filepath"
 
 	"github.com/ploMP4/chafa-go"
@@ -17,47 +19,80 @@ import (
 // docsBackend is an image backend tailored for documentation rendering.
 // It loads images from an embedded filesystem instead of the user's local FS.
 type docsBackend struct {
-	cache *chafaCache
+	// fallback draws the images chafa fails to.
+	fallback *nativeBackend
 }
 
-// NewDocsBackend creates a new instance of docsBackend with an initialized cache.
+// NewDocsBackend creates a new instance of docsBackend.
 func NewDocsBackend() *docsBackend {
 	return &docsBackend{
-		cache: &chafaCache{
-			cache: map[string]string{},
-		},
+		fallback: newDocsNativeBackend(),
 	}
 }
 
 func (b *docsBackend) SymbolsOnly() bool {
-	return b.detectTerminal().pixelMode == chafa.CHAFA_PIXEL_MODE_SYMBOLS
+	capabilities, _, err := terminal.detect()
+	if err != nil {
+		return b.fallback.SymbolsOnly()
+	}
+	return capabilities.pixelMode == chafa.CHAFA_PIXEL_MODE_SYMBOLS
+}
+
+func (b *docsBackend) Evict(path string) {
+	cache.Evict(path)
 }
 
-func (b *docsBackend) Render(path string, width, height int, symbols bool) (string, error) {
-	var err error
+func (b *docsBackend) Render(path string, width, height int, symbols bool) (out string, err error) {
+	capabilities, terminalKey, err := terminal.detect()
+	if err != nil {
+		return b.fallback.Render(path, width, height, symbols)
+	}
+
+	info, err := fs.Stat(docs.FS, path)
+	if err != nil {
+		return "", err
+	}
+	key := newCacheKey("docs", path, info, width, height, symbols, terminalKey)
+	if out, ok := cache.Get(key); ok {
+		return out, nil
+	}
 
 	defer func() {
 		if r := recover(); r != nil {
-			if recErr, ok := r.(error); ok {
-				err = recErr
-			} else {
-				err = fmt.Errorf("panic: %v", r)
-			}
+			err = panicError(r)
+		}
+		if err != nil {
+			slog.Warn("Chafa failed to render image, falling back to the native backend", "error", err, "path", path)
+			out, err = b.fallback.Render(path, width, height, symbols)
+		}
+		if err == nil {
+			cache.Put(key, out)
 		}
 	}()
 
-	c := b.cache.Get(path, width, height, symbols)
-	if c != "" {
-		return c, nil
-	}
+	return b.render(path, key.kittyID(), int32(width), int32(height), symbols, capabilities)
+}
 
-	out, err := b.render(path, int32(width), int32(height), symbols)
-	b.cache.Save(out, path, width, height, symbols)
+func (b *docsBackend) cached(path string, width, height int, symbols bool) (string, bool) {
+	_, terminalKey, err := terminal.detect()
+	if err != nil {
+		return b.fallback.cached(path, width, height, symbols)
+	}
 
-	return out, err
+	info, err := fs.Stat(docs.FS, path)
+	if err != nil {
+		return "", false
+	}
+	return cache.Get(newCacheKey("docs", path, info, width, height, symbols, terminalKey))
 }
 
-func (b docsBackend) render(path string, width, height int32, symbols bool) (string, error) {
+func (b docsBackend) render(
+	path string,
+	id uint32,
+	width, height int32,
+	symbols bool,
+	capabilities chafaTerminalCapabilities,
+) (string, error) {
 	pixels, pixelWidth, pixelHeight, err := b.load(path)
 	if err != nil {
 		return "", err
@@ -65,10 +100,6 @@ func (b docsBackend) render(path string, width, height int32, symbols bool) (str
 
 	chafa.CalcCanvasGeometry(width, height, &width, &height, 1, true, false)
 
-	capabilities := b.detectTerminal()
-	defer chafa.SymbolMapUnref(capabilities.symbolMap)
-	defer chafa.TermInfoUnref(capabilities.termInfo)
-
 	config := chafa.CanvasConfigNew()
 	defer chafa.CanvasConfigUnref(config)
 
@@ -76,7 +107,7 @@ func (b docsBackend) render(path string, width, height int32, symbols bool) (str
 	chafa.CanvasConfigSetGeometry(config, width, height)
 	chafa.CanvasConfigSetPassthrough(config, capabilities.passthrough)
 	chafa.CanvasConfigSetSymbolMap(config, capabilities.symbolMap)
-	chafa.CanvasConfigSetCellGeometry(config, 18, 36)
+	chafa.CanvasConfigSetCellGeometry(config, capabilities.cellWidth, capabilities.cellHeight)
 
 	if symbols {
 		chafa.CanvasConfigSetPixelMode(config, chafa.CHAFA_PIXEL_MODE_SYMBOLS)
@@ -97,30 +128,10 @@ func (b docsBackend) render(path string, width, height int32, symbols bool) (str
 	)
 	printable := chafa.CanvasPrint(canvas, nil)
 
-	return printable.String(), nil
-}
-
-func (b docsBackend) detectTerminal() chafaTerminalCapabilities {
-	termInfo := chafa.TermDbDetect(chafa.TermDbGetDefault(), os.Environ())
-
-	mode := chafa.TermInfoGetBestCanvasMode(termInfo)
-	pixelMode := chafa.TermInfoGetBestPixelMode(termInfo)
-
-	passthrough := chafa.CHAFA_PASSTHROUGH_NONE
-	if chafa.TermInfoGetIsPixelPassthroughNeeded(termInfo, pixelMode) {
-		passthrough = chafa.TermInfoGetPassthroughType(termInfo)
-	}
-
-	symbolMap := chafa.SymbolMapNew()
-	chafa.SymbolMapAddByTags(symbolMap, chafa.TermInfoGetSafeSymbolTags(termInfo))
-
-	return chafaTerminalCapabilities{
-		termInfo:    termInfo,
-		canvasMode:  mode,
-		pixelMode:   pixelMode,
-		passthrough: passthrough,
-		symbolMap:   symbolMap,
+	if capabilities.pixelMode == chafa.CHAFA_PIXEL_MODE_KITTY {
+		return tagKitty(printable.String(), id), nil
 	}
+	return printable.String(), nil
 }
 
 func (b docsBackend) load(path string) (pixels []uint8, width, height int32, err error) {
@@ -0,0 +1,239 @@
+package img
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Graphics is the protocol images are drawn with in pixels, on top of the
+// symbols laying them out.
+type Graphics string
+
+const (
+	// GraphicsAuto probes the terminal for the protocol it supports.
+	GraphicsAuto Graphics = "auto"
+	// GraphicsKitty draws images with the kitty graphics protocol, also
+	// supported by Ghostty and Konsole.
+	GraphicsKitty Graphics = "kitty"
+	// GraphicsSixel draws images as sixels, supported by foot, WezTerm,
+	// xterm and mlterm among others.
+	GraphicsSixel Graphics = "sixel"
+	// GraphicsITerm2 draws images with the inline images protocol of iTerm2,
+	// also supported by WezTerm.
+	GraphicsITerm2 Graphics = "iterm2"
+	// GraphicsSymbols draws images with Unicode symbols only.
+	GraphicsSymbols Graphics = "symbols"
+)
+
+// graphicsSetting is the protocol asked for and the one it resolves to, with
+// the size in pixels of the cells of the terminal images are drawn over.
+type graphicsSetting struct {
+	requested, resolved   Graphics
+	cellWidth, cellHeight int
+}
+
+// defaultCellWidth and defaultCellHeight are the size in pixels of the cells
+// of the terminals that do not report it, cells being about twice as high as
+// they are wide.
+const (
+	defaultCellWidth  = 10
+	defaultCellHeight = 20
+)
+
+var graphics atomic.Value
+
+// ParseGraphics returns the graphics protocol named s. An empty string is
+// [GraphicsAuto].
+func ParseGraphics(s string) (Graphics, error) {
+	switch g := Graphics(s); g {
+	case "":
+		return GraphicsAuto, nil
+	case GraphicsAuto, GraphicsKitty, GraphicsSixel, GraphicsITerm2, GraphicsSymbols:
+		return g, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid graphics %q, expected one of %s, %s, %s, %s or %s",
+			s,
+			GraphicsAuto,
+			GraphicsKitty,
+			GraphicsSixel,
+			GraphicsITerm2,
+			GraphicsSymbols,
+		)
+	}
+}
+
+// SetGraphics sets the protocol images rendered from now on are drawn with,
+// probing the terminal for [GraphicsAuto]. Unless drawing with symbols only,
+// it queries the terminal for the protocols it supports and the size of its
+// cells, so it must not be called while anything else reads the terminal.
+func SetGraphics(g Graphics) {
+	setting := graphicsSetting{
+		requested:  g,
+		resolved:   g,
+		cellWidth:  defaultCellWidth,
+		cellHeight: defaultCellHeight,
+	}
+
+	var reply terminalReply
+	if g != GraphicsSymbols {
+		reply = queryTerminal()
+	}
+	if reply.cellWidth > 0 && reply.cellHeight > 0 {
+		setting.cellWidth, setting.cellHeight = reply.cellWidth, reply.cellHeight
+	}
+	if g == GraphicsAuto {
+		setting.resolved = resolveGraphics(os.Getenv, reply)
+	}
+	graphics.Store(setting)
+}
+
+// CurrentGraphics returns the protocol set by [SetGraphics], the one the
+// terminal supports by default.
+func CurrentGraphics() Graphics {
+	return currentGraphics().resolved
+}
+
+// currentGraphics returns the setting of [SetGraphics]. Without one, the
+// protocol is probed for from the environment only, the terminal possibly
+// being read by a program already.
+func currentGraphics() graphicsSetting {
+	if s, ok := graphics.Load().(graphicsSetting); ok {
+		return s
+	}
+	graphics.CompareAndSwap(nil, graphicsSetting{
+		requested:  GraphicsAuto,
+		resolved:   resolveGraphics(os.Getenv, terminalReply{}),
+		cellWidth:  defaultCellWidth,
+		cellHeight: defaultCellHeight,
+	})
+	return graphics.Load().(graphicsSetting)
+}
+
+// resolveGraphics returns the protocol supported by the terminal that gave
+// reply to [queryTerminal], or else by the one described by the environment
+// variables getenv returns.
+func resolveGraphics(getenv func(string) string, reply terminalReply) Graphics {
+	// Terminal multiplexers swallow the escape sequences of every protocol,
+	// while answering the queries themselves
+	if getenv("TMUX") != "" || strings.HasPrefix(getenv("TERM"), "screen") {
+		return GraphicsSymbols
+	}
+
+	switch {
+	// WezTerm supports the kitty protocol only partly
+	case reply.iterm2:
+		return GraphicsITerm2
+	case reply.kitty:
+		return GraphicsKitty
+	case reply.sixel:
+		return GraphicsSixel
+	case reply.answered:
+		return GraphicsSymbols
+	default:
+		return probeGraphics(getenv)
+	}
+}
+
+// probeGraphics returns the protocol supported by the terminal described by
+// the environment variables getenv returns.
+func probeGraphics(getenv func(string) string) Graphics {
+	term, program := getenv("TERM"), getenv("TERM_PROGRAM")
+
+	switch {
+	// Terminal multiplexers swallow the escape sequences of every protocol
+	case getenv("TMUX") != "" || strings.HasPrefix(term, "screen"):
+		return GraphicsSymbols
+	case getenv("KITTY_WINDOW_ID") != "" || strings.Contains(term, "kitty") ||
+		program == "ghostty" || getenv("KONSOLE_VERSION") != "":
+		return GraphicsKitty
+	case program == "iTerm.app" || program == "WezTerm" || getenv("LC_TERMINAL") == "iTerm2":
+		return GraphicsITerm2
+	case strings.HasPrefix(term, "foot") || strings.Contains(term, "mlterm") ||
+		strings.Contains(term, "sixel") || program == "contour":
+		return GraphicsSixel
+	default:
+		return GraphicsSymbols
+	}
+}
+
+// kittyPlacement matches the placements of images with the kitty protocol,
+// capturing their id.
+var kittyPlacement = regexp.MustCompile(`\x1b_Ga=T,[^;\x1b]*?\bi=(\d+)`)
+
+var (
+	placedMu sync.Mutex
+	// placed holds the ids of the kitty images views have placed, false for
+	// those whose drawing left the cache since.
+	placed = map[uint32]bool{}
+)
+
+// forgetImage has the kitty image id, whose drawing left the cache, removed
+// along with its data by the next view not placing it, and no longer tracked.
+func forgetImage(id uint32) {
+	placedMu.Lock()
+	defer placedMu.Unlock()
+	if _, ok := placed[id]; ok {
+		placed[id] = false
+	}
+}
+
+// ClearHiddenImages returns the escape sequences removing the images placed
+// with the kitty protocol by earlier views that view does not place, by id
+// for the others to stay. They keep their data to be placed again. Images
+// no longer placed are removed again by every view, for the sequence not to
+// be lost with views the terminal is never sent, until their drawing leaves
+// the cache. Sixels and iTerm2 images
+// are replaced like text, and other terminals ignore the sequences.
+func ClearHiddenImages(view string) string {
+	if CurrentGraphics() != GraphicsKitty {
+		return ""
+	}
+
+	shown := map[uint32]bool{}
+	for _, m := range kittyPlacement.FindAllStringSubmatch(view, -1) {
+		if id, err := strconv.ParseUint(m[1], 10, 32); err == nil {
+			shown[uint32(id)] = true
+		}
+	}
+
+	placedMu.Lock()
+	defer placedMu.Unlock()
+
+	var sb strings.Builder
+	for _, id := range slices.Sorted(maps.Keys(placed)) {
+		switch {
+		case shown[id]:
+		case placed[id]:
+			fmt.Fprintf(&sb, "\x1b_Ga=d,d=i,i=%d,q=2\x1b\\", id)
+		default:
+			fmt.Fprintf(&sb, "\x1b_Ga=d,d=I,i=%d,q=2\x1b\\", id)
+			delete(placed, id)
+		}
+	}
+	for id := range shown {
+		placed[id] = true
+	}
+	return sb.String()
+}
+
+// Overlay returns symbols, an image laid out in cells, with pixels drawing
+// the same image over them from where they start. Kitty images are placed
+// first, over the text to come without moving the cursor, while sixels and
+// iTerm2 images replace the text under them, so the cursor goes back to
+// where the symbols start for them to be drawn last.
+func Overlay(symbols, pixels string) string {
+	if CurrentGraphics() == GraphicsKitty {
+		return pixels + symbols
+	}
+	return ansi.SaveCursor + symbols + ansi.RestoreCursor + pixels
+}
@@ -0,0 +1,331 @@
+package img
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestParseGraphics(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Graphics
+		wantErr bool
+	}{
+		{in: "", want: GraphicsAuto},
+		{in: "kitty", want: GraphicsKitty},
+		{in: "sixel", want: GraphicsSixel},
+		{in: "iterm2", want: GraphicsITerm2},
+		{in: "symbols", want: GraphicsSymbols},
+		{in: "ascii", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseGraphics(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseGraphics(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseGraphics(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestProbeGraphics(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Graphics
+	}{
+		{name: "kitty", env: map[string]string{"TERM": "xterm-kitty"}, want: GraphicsKitty},
+		{name: "ghostty", env: map[string]string{"TERM_PROGRAM": "ghostty"}, want: GraphicsKitty},
+		{name: "iTerm2", env: map[string]string{"TERM_PROGRAM": "iTerm.app"}, want: GraphicsITerm2},
+		{name: "foot", env: map[string]string{"TERM": "foot"}, want: GraphicsSixel},
+		{
+			name: "tmux",
+			env:  map[string]string{"TERM": "xterm-kitty", "TMUX": "/tmp/tmux-0/default"},
+			want: GraphicsSymbols,
+		},
+		{name: "unknown", env: map[string]string{"TERM": "xterm-256color"}, want: GraphicsSymbols},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := probeGraphics(getenv); got != tt.want {
+				t.Errorf("probeGraphics() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveGraphics(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		reply terminalReply
+		want  Graphics
+	}{
+		{name: "kitty", reply: terminalReply{answered: true, kitty: true, sixel: true}, want: GraphicsKitty},
+		{name: "sixel", reply: terminalReply{answered: true, sixel: true}, want: GraphicsSixel},
+		{name: "WezTerm", reply: terminalReply{answered: true, kitty: true, iterm2: true}, want: GraphicsITerm2},
+		{
+			name:  "no protocol",
+			env:   map[string]string{"TERM": "xterm-kitty"},
+			reply: terminalReply{answered: true},
+			want:  GraphicsSymbols,
+		},
+		{name: "no answer", env: map[string]string{"TERM": "foot"}, want: GraphicsSixel},
+		{
+			name:  "tmux",
+			env:   map[string]string{"TMUX": "/tmp/tmux-0/default"},
+			reply: terminalReply{answered: true, sixel: true},
+			want:  GraphicsSymbols,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := resolveGraphics(getenv, tt.reply); got != tt.want {
+				t.Errorf("resolveGraphics() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTerminalReply(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		want     terminalReply
+		wantDone bool
+	}{
+		{
+			name: "kitty",
+			in: "\x1b_Gi=31;OK\x1b\\" +
+				"\x1b[6;21;10t" +
+				"\x1bP>|kitty(0.39.1)\x1b\\" +
+				"\x1b[?62;c",
+			want:     terminalReply{answered: true, kitty: true, cellWidth: 10, cellHeight: 21},
+			wantDone: true,
+		},
+		{
+			name:     "sixel",
+			in:       "\x1b[6;16;8t\x1b[?62;4;22c",
+			want:     terminalReply{answered: true, sixel: true, cellWidth: 8, cellHeight: 16},
+			wantDone: true,
+		},
+		{
+			name:     "iTerm2",
+			in:       "\x1bP>|iTerm2 3.5.0\x1b\\\x1b[?62;4c",
+			want:     terminalReply{answered: true, sixel: true, iterm2: true},
+			wantDone: true,
+		},
+		{name: "partial", in: "\x1b_Gi=31;OK\x1b\\\x1b[6;21;10t"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, done := parseTerminalReply([]byte(tt.in))
+			if got != tt.want || done != tt.wantDone {
+				t.Errorf("parseTerminalReply() = %+v, %v, want %+v, %v", got, done, tt.want, tt.wantDone)
+			}
+		})
+	}
+}
+
+func TestClearHiddenImages(t *testing.T) {
+	SetGraphics(GraphicsKitty)
+	t.Cleanup(func() { SetGraphics(GraphicsAuto) })
+
+	placeA, err := encodeKitty(image.NewNRGBA(image.Rect(0, 0, 1, 1)), 1001, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	placeB := tagKitty("\x1b_Ga=T,f=32,s=1,v=1,c=1,r=1,m=0;AAAA\x1b\\", 1002)
+	deleteA := "\x1b_Ga=d,d=i,i=1001,q=2\x1b\\"
+
+	if got := ClearHiddenImages(placeA + placeB); got != "" {
+		t.Errorf("ClearHiddenImages() of the first view = %q, want nothing", got)
+	}
+	// Only the image no longer shown is removed, again until it is back
+	for range 2 {
+		if got := ClearHiddenImages(placeB); got != deleteA {
+			t.Errorf("ClearHiddenImages() without A = %q, want %q", got, deleteA)
+		}
+	}
+	if got := ClearHiddenImages(placeA + placeB); got != "" {
+		t.Errorf("ClearHiddenImages() with A back = %q, want nothing", got)
+	}
+
+	// Images whose drawing left the cache are removed with their data once
+	forgetImage(1001)
+	if got, want := ClearHiddenImages(placeB), "\x1b_Ga=d,d=I,i=1001,q=2\x1b\\"; got != want {
+		t.Errorf("ClearHiddenImages() without A evicted = %q, want %q", got, want)
+	}
+	if got := ClearHiddenImages(placeB); got != "" {
+		t.Errorf("ClearHiddenImages() once A is forgotten = %q, want nothing", got)
+	}
+
+	SetGraphics(GraphicsSixel)
+	if got := ClearHiddenImages(""); got != "" {
+		t.Errorf("ClearHiddenImages() with sixels = %q, want nothing", got)
+	}
+}
+
+func TestTagKitty(t *testing.T) {
+	got := tagKitty("\x1b_Ga=T,f=32,s=1,v=1,c=1,r=1,m=1\x1b\\\x1b_Gm=0;AAAA\x1b\\", 7)
+	want := "\x1b_Ga=T,i=7,p=1,q=2,f=32,s=1,v=1,c=1,r=1,m=1\x1b\\\x1b_Gm=0;AAAA\x1b\\"
+	if got != want {
+		t.Errorf("tagKitty() = %q, want %q", got, want)
+	}
+}
+
+func TestCacheKey_KittyID(t *testing.T) {
+	key := testKey("a")
+
+	// Other drawings of the same image, and of the image once changed, do
+	// not share its id
+	wider := key
+	wider.width = 20
+	changed := key
+	changed.modTime = time.Unix(2, 0)
+	for _, k := range []cacheKey{wider, changed, testKey("b")} {
+		if k.kittyID() == key.kittyID() {
+			t.Errorf("kittyID() of %+v = the id of %+v", k, key)
+		}
+	}
+	if key.kittyID() != testKey("a").kittyID() {
+		t.Error("kittyID() differs for the same drawing")
+	}
+}
+
+func TestEncodeKitty(t *testing.T) {
+	// Noise compresses badly, so the image takes several chunks
+	src := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(rng.Uint32())
+	}
+
+	out, err := encodeKitty(src, 42, 8, 4)
+	if err != nil {
+		t.Fatalf("encodeKitty() error = %v", err)
+	}
+
+	chunks := strings.SplitAfter(out, "\x1b\\")
+	chunks = chunks[:len(chunks)-1]
+	if len(chunks) < 2 {
+		t.Fatalf("encodeKitty() = %d chunks, want several", len(chunks))
+	}
+	if want := "\x1b_Ga=T,f=100,i=42,p=1,c=8,r=4,C=1,q=2,m=1;"; !strings.HasPrefix(chunks[0], want) {
+		t.Errorf("first chunk = %.60q, want prefix %q", chunks[0], want)
+	}
+	for i, c := range chunks[1:] {
+		want := "\x1b_Gm=1;"
+		if i == len(chunks)-2 {
+			want = "\x1b_Gm=0;"
+		}
+		if !strings.HasPrefix(c, want) {
+			t.Errorf("chunk %d = %.20q, want prefix %q", i+1, c, want)
+		}
+		if payload := strings.TrimSuffix(c[len(want):], "\x1b\\"); len(payload) > kittyChunk {
+			t.Errorf("chunk %d carries %d bytes, want at most %d", i+1, len(payload), kittyChunk)
+		}
+	}
+}
+
+func TestEncodeSixel(t *testing.T) {
+	// A column of red next to a column of blue, one band high
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 6))
+	for y := range 6 {
+		src.SetNRGBA(0, y, color.NRGBA{R: 0xff, A: 0xff})
+		src.SetNRGBA(1, y, color.NRGBA{B: 0xff, A: 0xff})
+	}
+
+	want := ansi.SaveCursor +
+		"\x1bP0;1;0q\"1;1;2;6" +
+		"#0;2;0;0;100#1;2;100;0;0" +
+		"#0?~$#1~" +
+		"\x1b\\" +
+		ansi.RestoreCursor
+	if got := encodeSixel(src); got != want {
+		t.Errorf("encodeSixel() = %q, want %q", got, want)
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	// 16 shades of gray and a transparent pixel quantized to 4 colors
+	src := image.NewNRGBA(image.Rect(0, 0, 17, 1))
+	for x := range 16 {
+		v := uint8(x * 17)
+		src.SetNRGBA(x, 0, color.NRGBA{R: v, G: v, B: v, A: 0xff})
+	}
+
+	palette, indices := quantize(src, 4)
+	if len(palette) != 4 {
+		t.Fatalf("quantize() = %d colors, want 4", len(palette))
+	}
+	if indices[16] != -1 {
+		t.Errorf("transparent pixel = %d, want -1", indices[16])
+	}
+
+	// Every color stands for 4 shades next to each other
+	for x := range 16 {
+		if indices[x] != indices[x/4*4] {
+			t.Errorf("pixel %d = color %d, want color %d of pixel %d", x, indices[x], indices[x/4*4], x/4*4)
+		}
+	}
+}
+
+func TestNativeBackend_Graphics(t *testing.T) {
+	t.Cleanup(func() { SetGraphics(GraphicsAuto) })
+
+	path := writePNG(t, image.NewNRGBA(image.Rect(0, 0, 4, 2)))
+
+	tests := []struct {
+		graphics Graphics
+		want     string
+	}{
+		{graphics: GraphicsKitty, want: "\x1b_Ga=T,f=100,i=%d,p=1,c=4,r=1,"},
+		{graphics: GraphicsSixel, want: ansi.SaveCursor + "\x1bP0;1;0q\"1;1;40;20"},
+		{graphics: GraphicsITerm2, want: ansi.SaveCursor + "\x1b]1337;File=inline=1;"},
+		{graphics: GraphicsSymbols, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.graphics), func(t *testing.T) {
+			SetGraphics(tt.graphics)
+			b := NewNativeBackend()
+
+			if got := b.SymbolsOnly(); got != (tt.graphics == GraphicsSymbols) {
+				t.Errorf("SymbolsOnly() = %v", got)
+			}
+
+			want := tt.want
+			if tt.graphics == GraphicsKitty {
+				key, err := b.key(path, 4, 10, false)
+				if err != nil {
+					t.Fatalf("key() error = %v", err)
+				}
+				want = fmt.Sprintf(want, key.kittyID())
+			}
+
+			out, err := b.Render(path, 4, 10, false)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !strings.HasPrefix(out, want) || (want == "" && out != "") {
+				t.Errorf("Render() = %.60q, want prefix %q", out, want)
+			}
+		})
+	}
+}
@@ -1,16 +1,49 @@
 package img
 
+import "sync"
+
 type ImageBackend interface {
 	SymbolsOnly() bool
 	Render(path string, width, height int, symbols bool) (string, error)
+	// Evict drops any cached rendering of the image at path so the next
+	// Render reads it from disk again.
+	Evict(path string)
 }
 
+var (
+	backendsMu sync.Mutex
+	// backends are shared by every slide drawing with them, for images to
+	// be drawn one at a time and an image shown on several slides only once.
+	backends = map[string]*asyncBackend{}
+)
+
+// Get returns the backend named backend, drawing images in the background
+// when [SetAsync] enables it. Every call with the same name returns the same
+// backend.
 func Get(backend string) ImageBackend {
+	switch backend {
+	case "docs", "native":
+	default:
+		backend = "chafa"
+	}
+
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	b, ok := backends[backend]
+	if !ok {
+		b = newAsyncBackend(newBackend(backend))
+		backends[backend] = b
+	}
+	return b
+}
+
+func newBackend(backend string) cachedBackend {
 	switch backend {
 	case "docs":
 		return NewDocsBackend()
-	case "chafa":
-		fallthrough
+	case "native":
+		return NewNativeBackend()
 	default:
 		return NewChafaBackend()
 	}
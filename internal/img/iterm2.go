@@ -0,0 +1,31 @@
+package img
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// encodeITerm2 draws img over cols by rows cells with the inline images
+// protocol of iTerm2, see https://iterm2.com/documentation-images.html. The
+// cursor is left where it was.
+func encodeITerm2(img image.Image, cols, rows int) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=0:%s\a%s",
+		ansi.SaveCursor,
+		buf.Len(),
+		cols,
+		rows,
+		base64.StdEncoding.EncodeToString(buf.Bytes()),
+		ansi.RestoreCursor,
+	), nil
+}
@@ -0,0 +1,72 @@
+package img
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/png"
+	"strings"
+)
+
+// kittyChunk is the most base64 encoded data a kitty graphics command can
+// carry.
+const kittyChunk = 4096
+
+// kittyID returns the id of the drawing under k, which is never 0. Drawings
+// of the same image at other sizes, or of the image once changed, have ids
+// of their own, for placing one not to replace or remove the others.
+func (k cacheKey) kittyID() uint32 {
+	h := fnv.New32a()
+	fmt.Fprintf(
+		h,
+		"%q %q %d %d %d %d %q",
+		k.backend,
+		k.path,
+		k.modTime.UnixNano(),
+		k.size,
+		k.width,
+		k.height,
+		k.capabilities,
+	)
+	return max(h.Sum32()&0xffffff, 1)
+}
+
+// tagKitty gives the images out places with the kitty protocol id, and the
+// placement encodeKitty gives them, for them to be removed by id like those
+// it draws. Replies are suppressed, terminals only sending them for images
+// with an id.
+func tagKitty(out string, id uint32) string {
+	return strings.ReplaceAll(out, "\x1b_Ga=T,", fmt.Sprintf("\x1b_Ga=T,i=%d,p=1,q=2,", id))
+}
+
+// encodeKitty draws img over cols by rows cells with the kitty graphics
+// protocol, see https://sw.kovidgoyal.net/kitty/graphics-protocol/. The image
+// is sent as PNG under id with a single placement, which drawing it again
+// replaces rather than adding another. The cursor does not move.
+func encodeKitty(img image.Image, id uint32, cols, rows int) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	var sb strings.Builder
+	for first := true; first || data != ""; first = false {
+		chunk := data[:min(kittyChunk, len(data))]
+		data = data[len(chunk):]
+
+		more := 0
+		if data != "" {
+			more = 1
+		}
+
+		sb.WriteString("\x1b_G")
+		if first {
+			fmt.Fprintf(&sb, "a=T,f=100,i=%d,p=1,c=%d,r=%d,C=1,q=2,", id, cols, rows)
+		}
+		fmt.Fprintf(&sb, "m=%d;%s\x1b\\", more, chunk)
+	}
+	return sb.String(), nil
+}
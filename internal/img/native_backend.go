@@ -0,0 +1,368 @@
+package img
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"github.com/museslabs/kyma/docs"
+)
+
+// nativeBackend draws images in pure Go, without chafa: with Unicode block
+// symbols, and in pixels with the protocol set by [SetGraphics]. It decodes
+// PNG, JPEG, GIF and WebP images.
+type nativeBackend struct {
+	// name tells the drawings of the backend apart in the cache from those
+	// of others, which may read paths from elsewhere.
+	name string
+	open func(path string) (io.ReadCloser, error)
+	stat func(path string) (fs.FileInfo, error)
+	// quadrants splits every cell in four pixels instead of two, which
+	// fonts of the Linux console lack.
+	quadrants bool
+	trueColor bool
+}
+
+// NewNativeBackend creates a native backend drawing the images of the local
+// filesystem for the current terminal.
+func NewNativeBackend() *nativeBackend {
+	return newNativeBackend(
+		"native",
+		func(path string) (io.ReadCloser, error) {
+			return os.Open(path)
+		},
+		os.Stat,
+	)
+}
+
+// newDocsNativeBackend creates a native backend drawing the images embedded
+// with the documentation.
+func newDocsNativeBackend() *nativeBackend {
+	return newNativeBackend(
+		"docs native",
+		func(path string) (io.ReadCloser, error) {
+			return docs.FS.Open(path)
+		},
+		func(path string) (fs.FileInfo, error) {
+			return fs.Stat(docs.FS, path)
+		},
+	)
+}
+
+func newNativeBackend(
+	name string,
+	open func(path string) (io.ReadCloser, error),
+	stat func(path string) (fs.FileInfo, error),
+) *nativeBackend {
+	colorTerm := os.Getenv("COLORTERM")
+	return &nativeBackend{
+		name:      name,
+		open:      open,
+		stat:      stat,
+		quadrants: os.Getenv("TERM") != "linux",
+		trueColor: colorTerm == "truecolor" || colorTerm == "24bit",
+	}
+}
+
+func (b *nativeBackend) SymbolsOnly() bool {
+	return CurrentGraphics() == GraphicsSymbols
+}
+
+func (b *nativeBackend) Evict(path string) {
+	cache.Evict(path)
+}
+
+func (b *nativeBackend) Render(path string, width, height int, symbols bool) (string, error) {
+	key, err := b.key(path, width, height, symbols)
+	if err != nil {
+		return "", err
+	}
+	if out, ok := cache.Get(key); ok {
+		return out, nil
+	}
+
+	src, err := b.load(path)
+	if err != nil {
+		return "", err
+	}
+
+	var out string
+	if symbols {
+		out = b.draw(src, width, height)
+	} else if out, err = b.encode(src, key.kittyID(), width, height); err != nil {
+		return "", err
+	}
+	cache.Put(key, out)
+	return out, nil
+}
+
+func (b *nativeBackend) cached(path string, width, height int, symbols bool) (string, bool) {
+	key, err := b.key(path, width, height, symbols)
+	if err != nil {
+		return "", false
+	}
+	return cache.Get(key)
+}
+
+func (b *nativeBackend) key(path string, width, height int, symbols bool) (cacheKey, error) {
+	info, err := b.stat(path)
+	if err != nil {
+		return cacheKey{}, err
+	}
+	setting := currentGraphics()
+	capabilities := fmt.Sprintf(
+		"quadrants=%t truecolor=%t graphics=%s cell=%dx%d",
+		b.quadrants,
+		b.trueColor,
+		setting.resolved,
+		setting.cellWidth,
+		setting.cellHeight,
+	)
+	return newCacheKey(b.name, path, info, width, height, symbols, capabilities), nil
+}
+
+func (b *nativeBackend) load(path string) (image.Image, error) {
+	file, err := b.open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	return img, err
+}
+
+// fit returns the cells an image of the size of bounds covers when fitted in
+// width by height cells, keeping its aspect ratio.
+func fit(bounds image.Rectangle, width, height int) (cols, rows int) {
+	if bounds.Empty() || width <= 0 || height <= 0 {
+		return 0, 0
+	}
+
+	cols = width
+	rows = max(cols*bounds.Dy()/(2*bounds.Dx()), 1)
+	if rows > height {
+		rows = height
+		cols = max(2*rows*bounds.Dx()/bounds.Dy(), 1)
+	}
+	return cols, rows
+}
+
+// encode draws src over the cells [nativeBackend.draw] lays it out in with
+// the protocol set by [SetGraphics], or nothing with symbols only. Kitty
+// images are drawn under id.
+func (b *nativeBackend) encode(src image.Image, id uint32, width, height int) (string, error) {
+	cols, rows := fit(src.Bounds(), width, height)
+	if cols == 0 {
+		return "", nil
+	}
+
+	// Images are drawn at the size of the cells they cover for the terminal
+	// not to scale them again
+	setting := currentGraphics()
+	scaled := image.NewNRGBA(image.Rect(0, 0, cols*setting.cellWidth, rows*setting.cellHeight))
+	draw.BiLinear.Scale(scaled, scaled.Bounds(), src, src.Bounds(), draw.Src, nil)
+
+	switch setting.resolved {
+	case GraphicsKitty:
+		return encodeKitty(scaled, id, cols, rows)
+	case GraphicsSixel:
+		return encodeSixel(scaled), nil
+	case GraphicsITerm2:
+		return encodeITerm2(scaled, cols, rows)
+	default:
+		return "", nil
+	}
+}
+
+// draw fits src in width by height cells, keeping its aspect ratio with
+// cells twice as high as they are wide.
+func (b *nativeBackend) draw(src image.Image, width, height int) string {
+	bounds := src.Bounds()
+	cols, rows := fit(bounds, width, height)
+	if cols == 0 {
+		return ""
+	}
+
+	// Every cell holds two pixels stacked, or four with quadrants
+	pxWidth := 1
+	if b.quadrants {
+		pxWidth = 2
+	}
+	scaled := image.NewNRGBA(image.Rect(0, 0, cols*pxWidth, rows*2))
+	draw.BiLinear.Scale(scaled, scaled.Bounds(), src, bounds, draw.Src, nil)
+
+	var sb strings.Builder
+	for y := range rows {
+		if y > 0 {
+			sb.WriteByte('\n')
+		}
+		last := ""
+		for x := range cols {
+			var pixels []color.NRGBA
+			if b.quadrants {
+				pixels = []color.NRGBA{
+					scaled.NRGBAAt(2*x, 2*y),
+					scaled.NRGBAAt(2*x+1, 2*y),
+					scaled.NRGBAAt(2*x, 2*y+1),
+					scaled.NRGBAAt(2*x+1, 2*y+1),
+				}
+			} else {
+				pixels = []color.NRGBA{
+					scaled.NRGBAAt(x, 2*y),
+					scaled.NRGBAAt(x, 2*y+1),
+				}
+			}
+			// Runs of cells in the same colors share their escape sequence
+			style, symbol := b.cell(pixels)
+			if style != last {
+				sb.WriteString(style)
+				last = style
+			}
+			sb.WriteRune(symbol)
+		}
+		sb.WriteString(ansi.ResetStyle)
+	}
+	return sb.String()
+}
+
+// halfBlocks and quadrantBlocks map which pixels of a cell are drawn in the
+// foreground color to the symbol drawing them, one bit per pixel from the top
+// left one to the bottom right one.
+var (
+	halfBlocks     = []rune{' ', '▀', '▄', '█'}
+	quadrantBlocks = []rune{
+		' ', '▘', '▝', '▀', '▖', '▌', '▞', '▛',
+		'▗', '▚', '▐', '▜', '▄', '▙', '▟', '█',
+	}
+)
+
+// cell returns the symbol best drawing pixels in two colors and the escape
+// sequence setting them, leaving transparent pixels to the background of the
+// terminal.
+func (b *nativeBackend) cell(pixels []color.NRGBA) (string, rune) {
+	blocks := halfBlocks
+	if b.quadrants {
+		blocks = quadrantBlocks
+	}
+	all := len(blocks) - 1
+
+	opaque := 0
+	for i, p := range pixels {
+		if p.A >= 0x80 {
+			opaque |= 1 << i
+		}
+	}
+
+	style := ansi.Style{}.Reset()
+	if opaque != all {
+		// Only the opaque pixels are drawn, with their average color
+		if opaque != 0 {
+			style = style.ForegroundColor(b.color(average(pixels, opaque)))
+		}
+		return style.String(), blocks[opaque]
+	}
+
+	// The pixels are split in the two groups closest to their average
+	// colors. A mask and its complement draw the same, so only those with
+	// the first pixel set are tried, a single color first.
+	best, bestErr := all, -1
+	for mask := all; mask > 0; mask -= 2 {
+		fg, bg := average(pixels, mask), average(pixels, all&^mask)
+		err := 0
+		for i, p := range pixels {
+			c := bg
+			if mask&(1<<i) != 0 {
+				c = fg
+			}
+			err += distance(p, c)
+		}
+		if bestErr < 0 || err < bestErr {
+			best, bestErr = mask, err
+		}
+	}
+
+	style = style.ForegroundColor(b.color(average(pixels, best)))
+	if best != all {
+		style = style.BackgroundColor(b.color(average(pixels, all&^best)))
+	}
+	return style.String(), blocks[best]
+}
+
+// color returns c as the terminal can show it.
+func (b *nativeBackend) color(c color.NRGBA) ansi.Color {
+	if b.trueColor {
+		return ansi.TrueColor(uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B))
+	}
+	return ansi.ExtendedColor(ansi256(c))
+}
+
+// average returns the average color of the pixels whose bit is set in mask.
+func average(pixels []color.NRGBA, mask int) color.NRGBA {
+	var r, g, b, n int
+	for i, p := range pixels {
+		if mask&(1<<i) == 0 {
+			continue
+		}
+		r, g, b = r+int(p.R), g+int(p.G), b+int(p.B)
+		n++
+	}
+	if n == 0 {
+		return color.NRGBA{}
+	}
+	return color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: 0xff}
+}
+
+func distance(a, b color.NRGBA) int {
+	dr, dg, db := int(a.R)-int(b.R), int(a.G)-int(b.G), int(a.B)-int(b.B)
+	return dr*dr + dg*dg + db*db
+}
+
+// cubeLevels are the intensities of the 6x6x6 color cube of the 256 color
+// palette.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// ansi256 returns the index of the color of the 256 color palette closest to
+// c, from its color cube or its gray ramp.
+func ansi256(c color.NRGBA) uint8 {
+	level := func(v uint8) int {
+		best := 0
+		for i, l := range cubeLevels {
+			if abs(int(v)-l) < abs(int(v)-cubeLevels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+
+	r, g, b := level(c.R), level(c.G), level(c.B)
+	cube := color.NRGBA{R: uint8(cubeLevels[r]), G: uint8(cubeLevels[g]), B: uint8(cubeLevels[b])}
+	index := 16 + 36*r + 6*g + b
+
+	// The gray ramp goes from 8 to 238 in 24 steps of 10
+	mean := (int(c.R) + int(c.G) + int(c.B)) / 3
+	step := min(max((mean-8+5)/10, 0), 23)
+	v := uint8(8 + 10*step)
+	if gray := (color.NRGBA{R: v, G: v, B: v}); distance(c, gray) < distance(c, cube) {
+		return uint8(232 + step)
+	}
+	return uint8(index)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,115 @@
+package img
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestNativeBackend_Render(t *testing.T) {
+	// A line of red on top of a line of blue, drawn in a line of cells
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for y := range 2 {
+		for x := range 4 {
+			c := color.NRGBA{R: 0xff, A: 0xff}
+			if y == 1 {
+				c = color.NRGBA{B: 0xff, A: 0xff}
+			}
+			src.SetNRGBA(x, y, c)
+		}
+	}
+
+	path := writePNG(t, src)
+
+	tests := []struct {
+		name      string
+		quadrants bool
+		trueColor bool
+		want      string
+	}{
+		{
+			name:      "half blocks in truecolor",
+			trueColor: true,
+			want:      "\x1b[0;38;2;255;0;0;48;2;0;0;255m▀",
+		},
+		{
+			name:      "quadrants in 256 colors",
+			quadrants: true,
+			want:      "\x1b[0;38;5;196;48;5;21m▀",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewNativeBackend()
+			b.quadrants, b.trueColor = tt.quadrants, tt.trueColor
+
+			out, err := b.Render(path, 4, 10, true)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			lines := strings.Split(out, "\n")
+			if len(lines) != 1 || ansi.StringWidth(lines[0]) != 4 {
+				t.Fatalf("Render() = %q, want one line of 4 cells", out)
+			}
+			if !strings.HasPrefix(lines[0], tt.want) {
+				t.Errorf("Render() = %q, want cells starting with %q", out, tt.want)
+			}
+		})
+	}
+}
+
+// writePNG writes img to a temporary file, returning its path.
+func writePNG(t *testing.T, img image.Image) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "image.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNativeBackend_Transparent(t *testing.T) {
+	b := NewNativeBackend()
+	b.quadrants, b.trueColor = true, true
+
+	style, symbol := b.cell([]color.NRGBA{
+		{R: 0xff, A: 0xff},
+		{},
+		{},
+		{R: 0xff, A: 0xff},
+	})
+	if want := "\x1b[0;38;2;255;0;0m"; style != want || symbol != '▚' {
+		t.Errorf("cell() = %q, %q, want %q, %q", style, symbol, want, '▚')
+	}
+}
+
+func TestANSI256(t *testing.T) {
+	tests := []struct {
+		c    color.NRGBA
+		want uint8
+	}{
+		{c: color.NRGBA{R: 0xff}, want: 196},
+		{c: color.NRGBA{R: 0xff, G: 0xff, B: 0xff}, want: 231},
+		{c: color.NRGBA{R: 0x80, G: 0x80, B: 0x80}, want: 244},
+	}
+
+	for _, tt := range tests {
+		if got := ansi256(tt.c); got != tt.want {
+			t.Errorf("ansi256(%v) = %d, want %d", tt.c, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+//go:build nochafa
+
+package img
+
+// Builds without chafa, which needs a shared library, draw every image with
+// the native backend.
+
+func NewChafaBackend() *nativeBackend {
+	return NewNativeBackend()
+}
+
+func NewDocsBackend() *nativeBackend {
+	return newDocsNativeBackend()
+}
@@ -0,0 +1,115 @@
+package img
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/muesli/cancelreader"
+	"golang.org/x/term"
+)
+
+// terminalReply is what the terminal answers the queries of [queryTerminal].
+type terminalReply struct {
+	// answered is whether the terminal answered at all.
+	answered bool
+	kitty    bool
+	sixel    bool
+	iterm2   bool
+	// cellWidth and cellHeight are the size of a cell in pixels, 0 when the
+	// terminal does not report it.
+	cellWidth, cellHeight int
+}
+
+// queryTimeout is how long the terminal is waited for, those answering
+// taking a few milliseconds at most.
+const queryTimeout = 200 * time.Millisecond
+
+const terminalQueries = "" +
+	// A 1 pixel image with the kitty protocol, which only the terminals
+	// supporting it answer with OK
+	"\x1b_Gi=31,s=1,v=1,a=q,t=d,f=24;AAAA\x1b\\" +
+	// The size of a cell in pixels
+	"\x1b[16t" +
+	// The name and version of the terminal
+	"\x1b[>0q" +
+	// The primary device attributes, sixels among them, which every
+	// terminal answers last
+	"\x1b[c"
+
+var (
+	kittyReply      = regexp.MustCompile(`\x1b_Gi=31;OK\x1b\\`)
+	cellSizeReply   = regexp.MustCompile(`\x1b\[6;(\d+);(\d+)t`)
+	versionReply    = regexp.MustCompile(`\x1bP>\|([^\x1b]*)\x1b\\`)
+	attributesReply = regexp.MustCompile(`\x1b\[\?([\d;]*)c`)
+)
+
+// queryTerminal asks the terminal of stdin and stdout for the protocols it
+// supports and the size of its cells. Nothing is asked when either is not a
+// terminal, and the reply is empty when the terminal does not answer in
+// time.
+func queryTerminal() terminalReply {
+	in, out := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(in) || !term.IsTerminal(out) {
+		return terminalReply{}
+	}
+
+	state, err := term.MakeRaw(in)
+	if err != nil {
+		return terminalReply{}
+	}
+	defer term.Restore(in, state)
+
+	// The reader is canceled rather than left blocked, for it not to take
+	// the input of what reads the terminal next
+	r, err := cancelreader.NewReader(os.Stdin)
+	if err != nil {
+		return terminalReply{}
+	}
+	defer r.Close()
+
+	if _, err := os.Stdout.WriteString(terminalQueries); err != nil {
+		return terminalReply{}
+	}
+
+	timer := time.AfterFunc(queryTimeout, func() { r.Cancel() })
+	defer timer.Stop()
+
+	var buf bytes.Buffer
+	chunk := make([]byte, 256)
+	for {
+		n, err := r.Read(chunk)
+		buf.Write(chunk[:n])
+		if reply, done := parseTerminalReply(buf.Bytes()); done || err != nil {
+			return reply
+		}
+	}
+}
+
+// parseTerminalReply parses what the terminal answered to
+// [terminalQueries], done once it answered them all.
+func parseTerminalReply(b []byte) (reply terminalReply, done bool) {
+	attributes := attributesReply.FindSubmatch(b)
+	if attributes == nil {
+		return terminalReply{}, false
+	}
+
+	reply.answered = true
+	reply.kitty = kittyReply.Match(b)
+	for _, attribute := range bytes.Split(attributes[1], []byte(";")) {
+		if string(attribute) == "4" {
+			reply.sixel = true
+		}
+	}
+	if version := versionReply.FindSubmatch(b); version != nil {
+		reply.iterm2 = bytes.HasPrefix(version[1], []byte("iTerm2")) ||
+			bytes.HasPrefix(version[1], []byte("WezTerm"))
+	}
+	if size := cellSizeReply.FindSubmatch(b); size != nil {
+		reply.cellHeight, _ = strconv.Atoi(string(size[1]))
+		reply.cellWidth, _ = strconv.Atoi(string(size[2]))
+	}
+	return reply, true
+}
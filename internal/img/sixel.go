@@ -0,0 +1,213 @@
+package img
+
+import (
+	"cmp"
+	"fmt"
+	"image"
+	"image/color"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxSixelColors is the size of the palette sixel images are drawn with,
+// the most terminals support.
+const maxSixelColors = 256
+
+// encodeSixel draws img as sixels, six rows of pixels at a time, with a
+// palette of at most [maxSixelColors] colors. Transparent pixels are left to
+// the background. The cursor is left where it was.
+func encodeSixel(img image.Image) string {
+	bounds := img.Bounds()
+	palette, indices := quantize(img, maxSixelColors)
+
+	var sb strings.Builder
+	sb.WriteString(ansi.SaveCursor)
+	// Pixels left out keep the background, and pixels are square
+	fmt.Fprintf(&sb, "\x1bP0;1;0q\"1;1;%d;%d", bounds.Dx(), bounds.Dy())
+	for i, c := range palette {
+		fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", i, int(c.R)*100/255, int(c.G)*100/255, int(c.B)*100/255)
+	}
+
+	width := bounds.Dx()
+	bits := make([]byte, width)
+	for band := 0; band < bounds.Dy(); band += 6 {
+		if band > 0 {
+			sb.WriteByte('-')
+		}
+
+		// Only the colors of the band are drawn, each over the whole band
+		// before going back to its start
+		used := map[int]bool{}
+		for y := band; y < min(band+6, bounds.Dy()); y++ {
+			for x := range width {
+				if i := indices[y*width+x]; i >= 0 {
+					used[i] = true
+				}
+			}
+		}
+
+		first := true
+		for _, i := range slices.Sorted(maps.Keys(used)) {
+			clear(bits)
+			for y := band; y < min(band+6, bounds.Dy()); y++ {
+				for x := range width {
+					if indices[y*width+x] == i {
+						bits[x] |= 1 << (y - band)
+					}
+				}
+			}
+
+			if !first {
+				sb.WriteByte('$')
+			}
+			first = false
+			fmt.Fprintf(&sb, "#%d", i)
+			writeSixels(&sb, bits)
+		}
+	}
+
+	sb.WriteString("\x1b\\")
+	sb.WriteString(ansi.RestoreCursor)
+	return sb.String()
+}
+
+// writeSixels writes the columns of a band, runs of the same column
+// compressed.
+func writeSixels(sb *strings.Builder, bits []byte) {
+	// Trailing empty columns need not be drawn
+	end := len(bits)
+	for end > 0 && bits[end-1] == 0 {
+		end--
+	}
+
+	for x := 0; x < end; {
+		run := 1
+		for x+run < end && bits[x+run] == bits[x] {
+			run++
+		}
+
+		c := byte('?' + bits[x])
+		if run > 3 {
+			fmt.Fprintf(sb, "!%d%c", run, c)
+		} else {
+			for range run {
+				sb.WriteByte(c)
+			}
+		}
+		x += run
+	}
+}
+
+// colorBox is a set of colors the median cut quantizer splits in two, with
+// the channel they spread the most over.
+type colorBox struct {
+	colors  []color.NRGBA
+	channel int
+	spread  int
+}
+
+func newColorBox(colors []color.NRGBA) colorBox {
+	b := colorBox{colors: colors}
+	for c := range 3 {
+		lo, hi := 255, 0
+		for _, p := range colors {
+			v := int(channel(p, c))
+			lo, hi = min(lo, v), max(hi, v)
+		}
+		if hi-lo > b.spread {
+			b.channel, b.spread = c, hi-lo
+		}
+	}
+	return b
+}
+
+// average returns the average color of the box.
+func (b colorBox) average() color.NRGBA {
+	var r, g, bl int
+	for _, p := range b.colors {
+		r, g, bl = r+int(p.R), g+int(p.G), bl+int(p.B)
+	}
+	n := len(b.colors)
+	return color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(bl / n), A: 0xff}
+}
+
+func channel(c color.NRGBA, i int) uint8 {
+	return [3]uint8{c.R, c.G, c.B}[i]
+}
+
+// quantize picks at most n colors drawing img with median cut, and returns
+// them along with the index of the color of every pixel, row by row, -1 for
+// transparent ones.
+func quantize(img image.Image, n int) ([]color.NRGBA, []int) {
+	bounds := img.Bounds()
+
+	var pixels []color.NRGBA
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA); c.A >= 0x80 {
+				c.A = 0xff
+				pixels = append(pixels, c)
+			}
+		}
+	}
+
+	// The box spreading the most is split at its median until there are
+	// enough, or none can be split
+	var boxes []colorBox
+	if len(pixels) > 0 {
+		boxes = append(boxes, newColorBox(pixels))
+	}
+	for len(boxes) < n {
+		widest := -1
+		for i, b := range boxes {
+			if b.spread > 0 && (widest < 0 || b.spread > boxes[widest].spread) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+
+		b := boxes[widest]
+		slices.SortFunc(b.colors, func(p, q color.NRGBA) int {
+			return cmp.Compare(channel(p, b.channel), channel(q, b.channel))
+		})
+		half := len(b.colors) / 2
+		boxes[widest] = newColorBox(b.colors[:half])
+		boxes = append(boxes, newColorBox(b.colors[half:]))
+	}
+
+	palette := make([]color.NRGBA, len(boxes))
+	for i, b := range boxes {
+		palette[i] = b.average()
+	}
+
+	indices := make([]int, 0, bounds.Dx()*bounds.Dy())
+	nearest := map[color.NRGBA]int{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A < 0x80 {
+				indices = append(indices, -1)
+				continue
+			}
+			c.A = 0xff
+
+			i, ok := nearest[c]
+			if !ok {
+				for j, p := range palette {
+					if distance(c, p) < distance(c, palette[i]) {
+						i = j
+					}
+				}
+				nearest[c] = i
+			}
+			indices = append(indices, i)
+		}
+	}
+
+	return palette, indices
+}
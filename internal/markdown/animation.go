@@ -0,0 +1,100 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// AnimationStart and AnimationEnd wrap the blocks with an entrance animation
+// in the output of [Renderer.RenderAnimated]. Both are zero width, so they
+// change neither wrapping nor padding.
+const (
+	AnimationStart = "\u200b"
+	AnimationEnd   = "\u200c"
+)
+
+// Animation is the entrance animation of a block of a slide, set with an
+// {animate=name} attribute at the end of a heading, list item or paragraph
+// line, or of the opening fence of a code block.
+type Animation struct {
+	Name string
+	// Code is set for code blocks, which are revealed line by line rather
+	// than character by character.
+	Code bool
+}
+
+var (
+	animationAttr = regexp.MustCompile(`\s*\{animate=([A-Za-z][\w-]*)\}\s*$`)
+	// blockPrefix matches what comes before the text of a line: indentation,
+	// heading and quote markers, and list bullets.
+	blockPrefix = regexp.MustCompile(`^\s*(?:#{1,6}\s+|>\s*)*(?:[-*+]\s+|\d+[.)]\s+)?`)
+	fence       = regexp.MustCompile("^\\s{0,3}(`{3,}|~{3,})")
+)
+
+// Animations returns the entrance animations of the blocks of in, in the
+// order they appear.
+func Animations(in string) []Animation {
+	_, animations := parseAnimations(in, false)
+	return animations
+}
+
+// RenderAnimated renders in like [Renderer.Render] does during transitions,
+// wrapping every block with an entrance animation in [AnimationStart] and
+// [AnimationEnd] for it to be found in the output.
+func (r *Renderer) RenderAnimated(in string) (string, error) {
+	marked, _ := parseAnimations(in, true)
+	return r.Render(marked, true)
+}
+
+// parseAnimations strips the animation attributes of in, returning the
+// markdown left and the animations they set. With mark, the animated blocks
+// are wrapped in markers.
+func parseAnimations(in string, mark bool) (string, []Animation) {
+	if !strings.Contains(in, "{animate=") {
+		return in, nil
+	}
+
+	lines := strings.Split(in, "\n")
+
+	var animations []Animation
+	// open is the fence of the code block the line is in, and code the
+	// index of the first line of an animated one, or -1
+	open, code := "", -1
+	for i, line := range lines {
+		if open != "" {
+			if trimmed := strings.TrimSpace(line); len(trimmed) >= len(open) && strings.Trim(trimmed, open[:1]) == "" {
+				if code >= 0 && code < i && mark {
+					lines[code] = AnimationStart + lines[code]
+					lines[i-1] += AnimationEnd
+				}
+				open, code = "", -1
+			}
+			continue
+		}
+
+		m := animationAttr.FindStringSubmatchIndex(line)
+		if f := fence.FindStringSubmatch(line); f != nil {
+			open = f[1]
+			if m != nil {
+				lines[i] = line[:m[0]]
+				animations = append(animations, Animation{Name: line[m[2]:m[3]], Code: true})
+				code = i + 1
+			}
+			continue
+		}
+		if m == nil {
+			continue
+		}
+
+		name := line[m[2]:m[3]]
+		line = line[:m[0]]
+		if mark {
+			prefix := len(blockPrefix.FindString(line))
+			line = line[:prefix] + AnimationStart + line[prefix:] + AnimationEnd
+		}
+		lines[i] = line
+		animations = append(animations, Animation{Name: name})
+	}
+
+	return strings.Join(lines, "\n"), animations
+}
@@ -0,0 +1,68 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnimations(t *testing.T) {
+	in := "# Title {animate=typewriter}\n" +
+		"- one {animate=slideIn}\n" +
+		"- two\n" +
+		"```go {animate=typewriter}\nfunc main() {}\n```\n" +
+		"```md\n# In code {animate=slideIn}\n```\n"
+
+	want := []Animation{
+		{Name: "typewriter"},
+		{Name: "slideIn"},
+		{Name: "typewriter", Code: true},
+	}
+
+	got := Animations(in)
+	if len(got) != len(want) {
+		t.Fatalf("Animations() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Animations()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	marked, _ := parseAnimations(in, true)
+	wantMarked := "# " + AnimationStart + "Title" + AnimationEnd + "\n" +
+		"- " + AnimationStart + "one" + AnimationEnd + "\n" +
+		"- two\n" +
+		"```go\n" + AnimationStart + "func main() {}" + AnimationEnd + "\n```\n" +
+		"```md\n# In code {animate=slideIn}\n```\n"
+	if marked != wantMarked {
+		t.Errorf("parseAnimations() = %q, want %q", marked, wantMarked)
+	}
+}
+
+func TestRenderAnimated(t *testing.T) {
+	r, err := NewRenderer("dark")
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	in := "# Title {animate=typewriter}\n\nSome text {animate=slideIn}\n"
+
+	out, err := r.Render(in, true)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if strings.Contains(out, "{animate=") || strings.Contains(out, AnimationStart) {
+		t.Errorf("Render() = %q, want the attributes stripped", out)
+	}
+
+	out, err = r.RenderAnimated(in)
+	if err != nil {
+		t.Fatalf("RenderAnimated() error = %v", err)
+	}
+	if got := strings.Count(out, AnimationStart); got != 2 {
+		t.Errorf("RenderAnimated() has %d animated blocks, want 2", got)
+	}
+	if got := strings.Count(out, AnimationEnd); got != 2 {
+		t.Errorf("RenderAnimated() has %d ends of animated blocks, want 2", got)
+	}
+}
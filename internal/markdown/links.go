@@ -0,0 +1,139 @@
+package markdown
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const slideLinkScheme = "slide:"
+
+// linkPattern matches inline markdown links. Images, which share the syntax
+// behind a `!`, are filtered out by the caller.
+var linkPattern = regexp.MustCompile(`(!?)\[([^\[\]]+)\]\(([^()\s]+)\)`)
+
+// Link is an inline markdown link.
+type Link struct {
+	Text   string
+	Target string
+}
+
+// Internal reports whether the link points to a slide of the deck, either by
+// anchor (#id) or by number (slide:N).
+func (l Link) Internal() bool {
+	return strings.HasPrefix(l.Target, "#") || strings.HasPrefix(l.Target, slideLinkScheme)
+}
+
+// External reports whether the link points to a web page.
+func (l Link) External() bool {
+	return strings.HasPrefix(l.Target, "http://") || strings.HasPrefix(l.Target, "https://")
+}
+
+// Links returns the inline links of in, in the order they appear. Links in
+// fenced code blocks are ignored.
+func Links(in string) []Link {
+	var links []Link
+
+	forEachProse(in, func(text string) string {
+		for _, m := range linkPattern.FindAllStringSubmatch(text, -1) {
+			if m[1] == "!" {
+				continue
+			}
+			links = append(links, Link{Text: m[2], Target: m[3]})
+		}
+		return text
+	})
+
+	return links
+}
+
+// rewriteSlideLinks turns slide:N links into anchors, which glamour renders
+// as their text alone instead of following it with the target.
+func rewriteSlideLinks(in string) string {
+	if !strings.Contains(in, slideLinkScheme) {
+		return in
+	}
+
+	return forEachProse(in, func(text string) string {
+		return linkPattern.ReplaceAllStringFunc(text, func(s string) string {
+			m := linkPattern.FindStringSubmatch(s)
+			if m[1] == "!" || !strings.HasPrefix(m[3], slideLinkScheme) {
+				return s
+			}
+			return m[1] + "[" + m[2] + "](#" + m[3] + ")"
+		})
+	})
+}
+
+// hyperlink wraps every occurrence of the targets of external links in out
+// with an OSC 8 escape sequence, so that terminals supporting it make them
+// clickable.
+func hyperlink(out string, links []Link) string {
+	var targets []string
+	for _, l := range links {
+		if l.External() && !slices.Contains(targets, l.Target) {
+			targets = append(targets, l.Target)
+		}
+	}
+	if len(targets) == 0 {
+		return out
+	}
+
+	// Longer targets first, so that a URL that is a prefix of another one
+	// does not split it
+	slices.SortFunc(targets, func(a, b string) int { return len(b) - len(a) })
+
+	pairs := make([]string, 0, 2*len(targets))
+	for _, target := range targets {
+		pairs = append(pairs, target, ansi.SetHyperlink(target)+target+ansi.ResetHyperlink())
+	}
+
+	return strings.NewReplacer(pairs...).Replace(out)
+}
+
+// forEachProse calls fn with every run of lines of in outside fenced code
+// blocks and returns in with each run replaced by what fn returned.
+func forEachProse(in string, fn func(text string) string) string {
+	var (
+		out   strings.Builder
+		prose strings.Builder
+		fence string
+	)
+
+	flush := func() {
+		if prose.Len() > 0 {
+			out.WriteString(fn(prose.String()))
+			prose.Reset()
+		}
+	}
+
+	for _, line := range strings.SplitAfter(in, "\n") {
+		trimmed := strings.TrimLeft(strings.TrimRight(line, "\n"), " ")
+
+		marker := ""
+		for _, c := range []string{"```", "~~~"} {
+			if strings.HasPrefix(trimmed, c) {
+				marker = trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, c[:1]))]
+			}
+		}
+
+		switch {
+		case marker != "" && fence == "":
+			flush()
+			fence = marker
+			out.WriteString(line)
+		case fence != "":
+			if marker != "" && strings.HasPrefix(marker, fence) {
+				fence = ""
+			}
+			out.WriteString(line)
+		default:
+			prose.WriteString(line)
+		}
+	}
+	flush()
+
+	return out.String()
+}
@@ -0,0 +1,70 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestLinks(t *testing.T) {
+	in := "See [the demo](#demo) and [slide twelve](slide:12).\n" +
+		"![an image](./img.png)\n" +
+		"```md\n[in code](#code)\n```\n" +
+		"Docs at [kyma](https://github.com/museslabs/kyma)\n"
+
+	want := []Link{
+		{Text: "the demo", Target: "#demo"},
+		{Text: "slide twelve", Target: "slide:12"},
+		{Text: "kyma", Target: "https://github.com/museslabs/kyma"},
+	}
+
+	got := Links(in)
+	if len(got) != len(want) {
+		t.Fatalf("Links() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Links()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if !got[0].Internal() || !got[1].Internal() || got[2].Internal() {
+		t.Error("Internal() should hold for anchors and slide links only")
+	}
+	if got[0].External() || !got[2].External() {
+		t.Error("External() should hold for http links only")
+	}
+}
+
+func TestRewriteSlideLinks(t *testing.T) {
+	in := "[back](slide:3) [demo](#demo)\n```\n[code](slide:1)\n```\n"
+	want := "[back](#slide:3) [demo](#demo)\n```\n[code](slide:1)\n```\n"
+
+	if got := rewriteSlideLinks(in); got != want {
+		t.Errorf("rewriteSlideLinks() = %q, want %q", got, want)
+	}
+}
+
+func TestHyperlink(t *testing.T) {
+	links := []Link{
+		{Text: "a", Target: "https://example.com"},
+		{Text: "b", Target: "https://example.com/docs"},
+		{Text: "c", Target: "#local"},
+	}
+
+	out := hyperlink("https://example.com/docs and https://example.com #local", links)
+
+	if got := ansi.Strip(out); got != "https://example.com/docs and https://example.com #local" {
+		t.Errorf("hyperlink() changed the visible text: %q", got)
+	}
+	if !strings.Contains(out, ansi.SetHyperlink("https://example.com/docs")+"https://example.com/docs"+ansi.ResetHyperlink()) {
+		t.Errorf("hyperlink() did not wrap the longer URL: %q", out)
+	}
+	if !strings.Contains(out, ansi.SetHyperlink("https://example.com")+"https://example.com"+ansi.ResetHyperlink()) {
+		t.Errorf("hyperlink() did not wrap the shorter URL: %q", out)
+	}
+	if strings.Contains(out, ansi.SetHyperlink("#local")) {
+		t.Errorf("hyperlink() should leave internal links alone: %q", out)
+	}
+}
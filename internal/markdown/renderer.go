@@ -1,7 +1,9 @@
 package markdown
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -27,6 +29,9 @@ type Renderer struct {
 type rendererOptions struct {
 	imgBackend img.ImageBackend
 	theme      string
+	// dir is the directory relative image paths are relative to, the
+	// working directory when empty.
+	dir string
 }
 
 func NewRenderer(theme string, options ...RendererOption) (*Renderer, error) {
@@ -62,16 +67,14 @@ func (r *Renderer) Render(in string, animating bool) (string, error) {
 func (r *Renderer) RenderBytes(in []byte, animating bool) (string, error) {
 	var b strings.Builder
 
-	// Clear kitty images
-	if !animating {
-		b.WriteString("\x1b_Ga=d\x1b\\")
-	}
+	// Animation attributes are only there for the slide, not the markdown
+	text, _ := parseAnimations(string(in), false)
 
-	for n := r.parser.Parse(in); n != nil; n = n.Next() {
+	for n := r.parse([]byte(text)); n != nil; n = n.Next() {
 		switch n.Kind() {
 		case NodeKindGlamour:
 			n := n.(*GlamourNode)
-			out, err := r.tr.Render(n.Text)
+			out, err := r.tr.Render(rewriteSlideLinks(n.Text))
 			if err != nil {
 				return "", err
 			}
@@ -81,6 +84,10 @@ func (r *Renderer) RenderBytes(in []byte, animating bool) (string, error) {
 			n := n.(*ImageNode)
 
 			limg, err := r.options.imgBackend.Render(n.Path, n.Width, n.Height, true)
+			if errors.Is(err, img.ErrPending) {
+				b.WriteString(imagePlaceholder(n))
+				continue
+			}
 			if err != nil {
 				b.WriteString(fmt.Sprintf("[Error rendering image: %s]", n.Label))
 				continue
@@ -91,17 +98,19 @@ func (r *Renderer) RenderBytes(in []byte, animating bool) (string, error) {
 				continue
 			}
 
+			// The symbols stand in for the pixels until they are drawn
 			himg, err := r.options.imgBackend.Render(n.Path, n.Width, n.Height, false)
+			if errors.Is(err, img.ErrPending) {
+				b.WriteString(limg)
+				continue
+			}
 			if err != nil {
 				b.WriteString(fmt.Sprintf("[Error rendering image: %s]", n.Label))
 				continue
 			}
 
 			if !animating {
-				b.WriteString(ansi.SaveCursor)
-				b.WriteString(limg)
-				b.WriteString(ansi.RestoreCursor)
-				b.WriteString(himg)
+				b.WriteString(img.Overlay(limg, himg))
 			} else {
 				b.WriteString(limg)
 			}
@@ -135,9 +144,81 @@ func (r *Renderer) RenderBytes(in []byte, animating bool) (string, error) {
 		}
 	}
 
+	// Transitions cut lines with tools unaware of OSC 8, so only make links
+	// clickable once the slide is still
+	if !animating {
+		return hyperlink(b.String(), Links(string(in))), nil
+	}
+
 	return b.String(), nil
 }
 
+// parse parses in into nodes, with the paths of images relative to the
+// directory of [WithDir].
+func (r *Renderer) parse(in []byte) Node {
+	root := r.parser.Parse(in)
+	if r.options.dir == "" {
+		return root
+	}
+
+	for n := root; n != nil; n = n.Next() {
+		if n, ok := n.(*ImageNode); ok && !filepath.IsAbs(n.Path) {
+			n.Path = filepath.Join(r.options.dir, n.Path)
+		}
+	}
+	return root
+}
+
+// Images returns the paths of every image referenced by in, in the order they
+// appear, relative to the directory of [WithDir].
+func (r *Renderer) Images(in string) []string {
+	var paths []string
+	for n := r.parse([]byte(in)); n != nil; n = n.Next() {
+		if n, ok := n.(*ImageNode); ok {
+			paths = append(paths, n.Path)
+		}
+	}
+	return paths
+}
+
+// Prefetch starts drawing the images referenced by in, for them to be ready
+// when it is rendered, if the image backend draws them in the background.
+func (r *Renderer) Prefetch(in string) {
+	p, ok := r.options.imgBackend.(img.Prefetcher)
+	if !ok {
+		return
+	}
+	for n := r.parse([]byte(in)); n != nil; n = n.Next() {
+		if n, ok := n.(*ImageNode); ok {
+			p.Prefetch(n.Path, n.Width, n.Height)
+		}
+	}
+}
+
+// imagePlaceholder returns the box drawn in place of an image still loading,
+// the size of the image when it has one.
+func imagePlaceholder(n *ImageNode) string {
+	label := fmt.Sprintf("Loading %s", n.Label)
+	if n.Width < 3 || n.Height < 3 {
+		return fmt.Sprintf("[%s]", label)
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Foreground(lipgloss.Color("240")).
+		Width(n.Width-2).
+		Height(n.Height-2).
+		MaxHeight(n.Height).
+		Align(lipgloss.Center, lipgloss.Center).
+		Render(ansi.Truncate(label, n.Width-2, "…"))
+}
+
+// Evict drops the cached renderings of the image at path.
+func (r *Renderer) Evict(path string) {
+	r.options.imgBackend.Evict(path)
+}
+
 func WithImageBackend(backend string) RendererOption {
 	return func(r *Renderer) error {
 		r.options.imgBackend = img.Get(backend)
@@ -145,6 +226,15 @@ func WithImageBackend(backend string) RendererOption {
 	}
 }
 
+// WithDir makes relative image paths relative to dir, the directory of the
+// file the markdown was read from, rather than to the working directory.
+func WithDir(dir string) RendererOption {
+	return func(r *Renderer) error {
+		r.options.dir = dir
+		return nil
+	}
+}
+
 func (r *Renderer) formatLineNumber(lineNum, width int) string {
 	style := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("240")).
@@ -2,8 +2,11 @@ package markdown
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/charmbracelet/x/exp/golden"
 )
 
@@ -119,3 +122,27 @@ func TestRenderer_RenderCodeBlockWithStartFromLine(t *testing.T) {
 
 	golden.RequireEqual(t, got)
 }
+
+func TestImagePlaceholder(t *testing.T) {
+	tests := []struct {
+		name   string
+		node   *ImageNode
+		width  int
+		height int
+	}{
+		{name: "sized", node: &ImageNode{Label: "logo", Width: 20, Height: 6}, width: 20, height: 6},
+		{name: "unsized", node: &ImageNode{Label: "logo"}, width: len("[Loading logo]"), height: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := imagePlaceholder(tt.node)
+			if w, h := lipgloss.Size(got); w != tt.width || h != tt.height {
+				t.Errorf("imagePlaceholder() is %dx%d, want %dx%d:\n%s", w, h, tt.width, tt.height, got)
+			}
+			if !strings.Contains(ansi.Strip(got), "Loading logo") {
+				t.Errorf("imagePlaceholder() = %q, want the label", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Package record writes the screens of a presentation to files that play it
+// back without kyma, as an asciinema cast or an animated GIF.
+package record
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// Frame is a screen shown for a while during a recording.
+type Frame struct {
+	Screen   string
+	Duration time.Duration
+}
+
+// castHeader is the first line of an asciicast v2 file.
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Env       map[string]string `json:"env"`
+}
+
+// WriteCast writes frames of width by height cells as an asciicast v2
+// recording, see https://docs.asciinema.org/manual/asciicast/v2/.
+func WriteCast(w io.Writer, frames []Frame, width, height int) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	header := castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: time.Now().Unix(),
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	}
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+
+	// Hide the cursor, which would otherwise sit at the end of every frame
+	if err := enc.Encode([]any{0.0, "o", "\x1b[?25l"}); err != nil {
+		return err
+	}
+
+	var elapsed time.Duration
+	for _, frame := range frames {
+		screen := "\x1b[H\x1b[2J" + strings.ReplaceAll(frame.Screen, "\n", "\r\n")
+		if err := enc.Encode([]any{elapsed.Seconds(), "o", screen}); err != nil {
+			return err
+		}
+		elapsed += frame.Duration
+	}
+
+	// Players stop at the last event, which would cut the last frame short
+	return enc.Encode([]any{elapsed.Seconds(), "o", "\x1b[?25h"})
+}
@@ -0,0 +1,54 @@
+package record
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestWriteCast(t *testing.T) {
+	frames := []Frame{
+		{Screen: "one\ntwo", Duration: 2 * time.Second},
+		{Screen: "<b>", Duration: 500 * time.Millisecond},
+	}
+
+	var out bytes.Buffer
+	if err := WriteCast(&out, frames, 10, 2); err != nil {
+		t.Fatalf("WriteCast() error = %v", err)
+	}
+
+	scanner := bufio.NewScanner(&out)
+	scanner.Scan()
+
+	var header castHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		t.Fatalf("header %q: %v", scanner.Text(), err)
+	}
+	if header.Version != 2 || header.Width != 10 || header.Height != 2 {
+		t.Errorf("header = %+v, want version 2 of 10x2", header)
+	}
+
+	var events [][]any
+	for scanner.Scan() {
+		var event []any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("event %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+	if events[1][0] != 0.0 || events[1][2] != "\x1b[H\x1b[2Jone\r\ntwo" {
+		t.Errorf("first frame = %v", events[1])
+	}
+	if events[2][0] != 2.0 || events[2][2] != "\x1b[H\x1b[2J<b>" {
+		t.Errorf("second frame = %v", events[2])
+	}
+	if events[3][0] != 2.5 {
+		t.Errorf("last event at %v, want the end of the last frame", events[3][0])
+	}
+}
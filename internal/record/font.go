@@ -0,0 +1,275 @@
+package record
+
+import (
+	"image"
+
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Cells are drawn with the 7x13 X11 fixed font, which only covers ASCII.
+// Box drawing, block and braille characters, which borders and images are
+// made of, are drawn from their shapes instead.
+const (
+	cellWidth  = 7
+	cellHeight = 13
+)
+
+var face = basicfont.Face7x13
+
+// folds maps common characters the font lacks to the closest ASCII ones.
+var folds = map[rune]rune{
+	'‘': '\'',
+	'’': '\'',
+	'“': '"',
+	'”': '"',
+	'–': '-',
+	'—': '-',
+	'·': '.',
+	'←': '<',
+	'→': '>',
+	'↑': '^',
+	'↓': 'v',
+	'×': 'x',
+	'✓': 'v',
+	'✔': 'v',
+	'✗': 'x',
+	'✘': 'x',
+}
+
+// arms are the sides of a cell a box drawing character reaches.
+type arms struct {
+	up, down, left, right bool
+	// weight is 1 for light lines, 2 for heavy ones and 3 for double ones.
+	weight int
+}
+
+var boxes = map[rune]arms{
+	'─': {left: true, right: true, weight: 1},
+	'│': {up: true, down: true, weight: 1},
+	'┌': {down: true, right: true, weight: 1},
+	'┐': {down: true, left: true, weight: 1},
+	'└': {up: true, right: true, weight: 1},
+	'┘': {up: true, left: true, weight: 1},
+	'├': {up: true, down: true, right: true, weight: 1},
+	'┤': {up: true, down: true, left: true, weight: 1},
+	'┬': {down: true, left: true, right: true, weight: 1},
+	'┴': {up: true, left: true, right: true, weight: 1},
+	'┼': {up: true, down: true, left: true, right: true, weight: 1},
+	'╭': {down: true, right: true, weight: 1},
+	'╮': {down: true, left: true, weight: 1},
+	'╯': {up: true, left: true, weight: 1},
+	'╰': {up: true, right: true, weight: 1},
+	'╴': {left: true, weight: 1},
+	'╵': {up: true, weight: 1},
+	'╶': {right: true, weight: 1},
+	'╷': {down: true, weight: 1},
+	'┄': {left: true, right: true, weight: 1},
+	'┈': {left: true, right: true, weight: 1},
+	'╌': {left: true, right: true, weight: 1},
+	'┆': {up: true, down: true, weight: 1},
+	'┊': {up: true, down: true, weight: 1},
+	'╎': {up: true, down: true, weight: 1},
+	'━': {left: true, right: true, weight: 2},
+	'┃': {up: true, down: true, weight: 2},
+	'┏': {down: true, right: true, weight: 2},
+	'┓': {down: true, left: true, weight: 2},
+	'┗': {up: true, right: true, weight: 2},
+	'┛': {up: true, left: true, weight: 2},
+	'┣': {up: true, down: true, right: true, weight: 2},
+	'┫': {up: true, down: true, left: true, weight: 2},
+	'┳': {down: true, left: true, right: true, weight: 2},
+	'┻': {up: true, left: true, right: true, weight: 2},
+	'╋': {up: true, down: true, left: true, right: true, weight: 2},
+	'═': {left: true, right: true, weight: 3},
+	'║': {up: true, down: true, weight: 3},
+	'╔': {down: true, right: true, weight: 3},
+	'╗': {down: true, left: true, weight: 3},
+	'╚': {up: true, right: true, weight: 3},
+	'╝': {up: true, left: true, weight: 3},
+	'╠': {up: true, down: true, right: true, weight: 3},
+	'╣': {up: true, down: true, left: true, weight: 3},
+	'╦': {down: true, left: true, right: true, weight: 3},
+	'╩': {up: true, left: true, right: true, weight: 3},
+	'╬': {up: true, down: true, left: true, right: true, weight: 3},
+}
+
+// quadrants maps the quadrant block characters to the quarters of the cell
+// they fill: upper left, upper right, lower left and lower right.
+var quadrants = map[rune][4]bool{
+	'▖': {false, false, true, false},
+	'▗': {false, false, false, true},
+	'▘': {true, false, false, false},
+	'▙': {true, false, true, true},
+	'▚': {true, false, false, true},
+	'▛': {true, true, true, false},
+	'▜': {true, true, false, true},
+	'▝': {false, true, false, false},
+	'▞': {false, true, true, false},
+	'▟': {false, true, true, true},
+}
+
+// drawGlyph draws r in the cell whose top left pixel is origin, calling set
+// for every pixel of the foreground.
+func drawGlyph(r rune, origin image.Point, bold bool, set func(x, y int)) {
+	// Pixels are set relative to the cell, and clipped to it
+	cell := func(x, y int) {
+		if x >= 0 && x < cellWidth && y >= 0 && y < cellHeight {
+			set(origin.X+x, origin.Y+y)
+		}
+	}
+	rect := func(x0, y0, x1, y1 int) {
+		for y := y0; y < y1; y++ {
+			for x := x0; x < x1; x++ {
+				cell(x, y)
+			}
+		}
+	}
+
+	if a, ok := boxes[r]; ok {
+		drawBox(a, cell)
+		return
+	}
+	if q, ok := quadrants[r]; ok {
+		halfW, halfH := (cellWidth+1)/2, (cellHeight+1)/2
+		for i, filled := range q {
+			if filled {
+				x, y := i%2*halfW, i/2*halfH
+				rect(x, y, x+halfW, y+halfH)
+			}
+		}
+		return
+	}
+
+	switch {
+	case r == ' ' || r == '\u00a0':
+		return
+	case r == '█':
+		rect(0, 0, cellWidth, cellHeight)
+		return
+	case r == '▀':
+		rect(0, 0, cellWidth, cellHeight/2)
+		return
+	case r == '▐':
+		rect(cellWidth/2+1, 0, cellWidth, cellHeight)
+		return
+	case r == '▄':
+		rect(0, cellHeight/2, cellWidth, cellHeight)
+		return
+	case r == '▌':
+		rect(0, 0, cellWidth/2+1, cellHeight)
+		return
+	case r >= '▁' && r <= '▇':
+		// Lower eighths
+		h := int(r-'▁'+1) * cellHeight / 8
+		rect(0, cellHeight-h, cellWidth, cellHeight)
+		return
+	case r >= '▉' && r <= '▏':
+		// Left eighths, from seven down to one
+		w := int('▏'-r+1) * cellWidth / 8
+		rect(0, 0, max(w, 1), cellHeight)
+		return
+	case r >= '░' && r <= '▓':
+		// Shades, as a pattern covering a quarter, half or three quarters
+		// of the cell
+		density := int(r-'░') + 1
+		for y := range cellHeight {
+			for x := range cellWidth {
+				if (x+2*y)%4 < density {
+					cell(x, y)
+				}
+			}
+		}
+		return
+	case r >= 0x2800 && r <= 0x28ff:
+		drawBraille(byte(r-0x2800), cell)
+		return
+	case r == '•' || r == '∙':
+		rect(2, 5, 5, 8)
+		return
+	case r == '●' || r == '⬤':
+		rect(1, 3, 6, 10)
+		return
+	case r == '…':
+		for _, x := range []int{0, 3, 6} {
+			cell(x, 10)
+		}
+		return
+	}
+
+	if folded, ok := folds[r]; ok {
+		r = folded
+	}
+
+	dot := fixed.P(0, face.Ascent)
+	dr, mask, maskp, _, ok := face.Glyph(dot, r)
+	if !ok {
+		dr, mask, maskp, _, _ = face.Glyph(dot, '�')
+	}
+	for y := dr.Min.Y; y < dr.Max.Y; y++ {
+		for x := dr.Min.X; x < dr.Max.X; x++ {
+			if _, _, _, a := mask.At(maskp.X+x-dr.Min.X, maskp.Y+y-dr.Min.Y).RGBA(); a == 0 {
+				continue
+			}
+			cell(x, y)
+			if bold {
+				cell(x+1, y)
+			}
+		}
+	}
+}
+
+// drawBox draws the lines of a box drawing character from the center of the
+// cell to the sides it reaches.
+func drawBox(a arms, cell func(x, y int)) {
+	cx, cy := cellWidth/2, cellHeight/2
+
+	// Heavy lines are two pixels wide, double ones two lines a pixel apart
+	offsets := []int{0}
+	switch a.weight {
+	case 2:
+		offsets = []int{0, 1}
+	case 3:
+		offsets = []int{-1, 1}
+	}
+
+	for _, o := range offsets {
+		if a.left {
+			for x := 0; x <= cx; x++ {
+				cell(x, cy+o)
+			}
+		}
+		if a.right {
+			for x := cx; x < cellWidth; x++ {
+				cell(x, cy+o)
+			}
+		}
+		if a.up {
+			for y := 0; y <= cy; y++ {
+				cell(cx+o, y)
+			}
+		}
+		if a.down {
+			for y := cy; y < cellHeight; y++ {
+				cell(cx+o, y)
+			}
+		}
+	}
+}
+
+// drawBraille draws the dots of a braille pattern, whose bits number the dots
+// down the left column first, then the right one, the bottom row last.
+func drawBraille(dots byte, cell func(x, y int)) {
+	positions := [8][2]int{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}, {0, 3}, {1, 3}}
+
+	for bit, pos := range positions {
+		if dots&(1<<bit) == 0 {
+			continue
+		}
+		x, y := 1+pos[0]*3, 1+pos[1]*3
+		cell(x, y)
+		cell(x+1, y)
+		cell(x, y+1)
+		cell(x+1, y+1)
+	}
+}
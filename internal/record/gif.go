@@ -0,0 +1,216 @@
+package record
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/cellbuf"
+)
+
+// Colors of the cells that leave theirs to the terminal.
+var (
+	defaultForeground = color.RGBA{0xd0, 0xd0, 0xd0, 0xff}
+	defaultBackground = color.RGBA{0x00, 0x00, 0x00, 0xff}
+)
+
+// graphics matches the escape sequences drawing or clearing images, as
+// application program commands and device control strings, which cells
+// cannot hold.
+var graphics = regexp.MustCompile("\x1b[_P][^\x1b]*\x1b\\\\")
+
+// minDelay is the shortest time a GIF frame is shown for, in hundredths of a
+// second. Most players slow shorter frames down to a tenth of a second.
+const minDelay = 2
+
+// gifFrame is a screen and how long it is shown for, in hundredths of a
+// second.
+type gifFrame struct {
+	screen string
+	delay  int
+}
+
+// WriteGIF writes frames of width by height cells as an animated GIF.
+// Each frame only holds the cells that changed since the previous one.
+func WriteGIF(w io.Writer, frames []Frame, width, height int) error {
+	anim := &gif.GIF{}
+
+	var prev *cellbuf.Buffer
+	for _, frame := range gifTimeline(frames) {
+		buf := cellbuf.NewBuffer(width, height)
+		cellbuf.SetContent(buf, graphics.ReplaceAllString(frame.screen, ""))
+
+		changed := changedCells(prev, buf)
+		if changed.Empty() {
+			anim.Delay[len(anim.Delay)-1] += frame.delay
+			continue
+		}
+
+		anim.Image = append(anim.Image, renderCells(buf, changed))
+		anim.Delay = append(anim.Delay, frame.delay)
+		anim.Disposal = append(anim.Disposal, gif.DisposalNone)
+		prev = buf
+	}
+
+	return gif.EncodeAll(w, anim)
+}
+
+// gifTimeline lines frames up on the hundredths of a second GIF delays are
+// counted in. Frames that would be shown for less than [minDelay] are
+// replaced by the ones following them.
+func gifTimeline(frames []Frame) []gifFrame {
+	centiseconds := func(d time.Duration) int {
+		return int(d / (10 * time.Millisecond))
+	}
+
+	var timeline []gifFrame
+	var elapsed time.Duration
+	last := 0
+	for _, frame := range frames {
+		start := centiseconds(elapsed)
+		elapsed += frame.Duration
+
+		if n := len(timeline); n > 0 {
+			if start-last < minDelay {
+				timeline[n-1].screen = frame.Screen
+				continue
+			}
+			timeline[n-1].delay = start - last
+		}
+		timeline = append(timeline, gifFrame{screen: frame.Screen})
+		last = start
+	}
+
+	if n := len(timeline); n > 0 {
+		timeline[n-1].delay = max(centiseconds(elapsed)-last, minDelay)
+	}
+	return timeline
+}
+
+// changedCells returns the bounds of the cells that differ between prev and
+// next, all of them if there is no prev.
+func changedCells(prev, next *cellbuf.Buffer) image.Rectangle {
+	if prev == nil {
+		return next.Bounds()
+	}
+
+	var changed image.Rectangle
+	for y := range next.Height() {
+		for x := range next.Width() {
+			if c := next.Cell(x, y); c == nil && prev.Cell(x, y) == nil || c != nil && c.Equal(prev.Cell(x, y)) {
+				continue
+			}
+			changed = changed.Union(image.Rect(x, y, x+1, y+1))
+		}
+	}
+	return changed
+}
+
+// renderCells draws the cells of buf within bounds, placed where they are on
+// the screen.
+func renderCells(buf *cellbuf.Buffer, bounds image.Rectangle) *image.Paletted {
+	type colors struct{ fg, bg color.RGBA }
+
+	cells := make([]colors, bounds.Dx()*bounds.Dy())
+	used := map[color.RGBA]struct{}{}
+	var pal color.Palette
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			fg, bg := cellColors(buf.Cell(x, y))
+			cells[(y-bounds.Min.Y)*bounds.Dx()+x-bounds.Min.X] = colors{fg, bg}
+			for _, c := range []color.RGBA{fg, bg} {
+				if _, ok := used[c]; !ok {
+					used[c] = struct{}{}
+					pal = append(pal, c)
+				}
+			}
+		}
+	}
+
+	// Text only ever uses the colors of its cells, which usually fit in a
+	// GIF palette. Those that do not, from images or blended transitions, are
+	// drawn with the closest colors of a standard palette.
+	if len(pal) > 256 {
+		pal = palette.Plan9
+	}
+	indices := map[color.RGBA]uint8{}
+	index := func(c color.RGBA) uint8 {
+		i, ok := indices[c]
+		if !ok {
+			i = uint8(pal.Index(c))
+			indices[c] = i
+		}
+		return i
+	}
+
+	img := image.NewPaletted(image.Rect(
+		bounds.Min.X*cellWidth,
+		bounds.Min.Y*cellHeight,
+		bounds.Max.X*cellWidth,
+		bounds.Max.Y*cellHeight,
+	), pal)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			colors := cells[(y-bounds.Min.Y)*bounds.Dx()+x-bounds.Min.X]
+			origin := image.Pt(x*cellWidth, y*cellHeight)
+
+			bg := index(colors.bg)
+			for py := origin.Y; py < origin.Y+cellHeight; py++ {
+				for px := origin.X; px < origin.X+cellWidth; px++ {
+					img.SetColorIndex(px, py, bg)
+				}
+			}
+
+			c := buf.Cell(x, y)
+			if c == nil || c.Width == 0 || c.Style.Attrs&cellbuf.ConcealAttr != 0 {
+				continue
+			}
+
+			fg := index(colors.fg)
+			set := func(px, py int) { img.SetColorIndex(px, py, fg) }
+
+			drawGlyph(c.Rune, origin, c.Style.Attrs&cellbuf.BoldAttr != 0, set)
+			if c.Style.UlStyle != cellbuf.NoUnderline {
+				for px := origin.X; px < origin.X+cellWidth; px++ {
+					set(px, origin.Y+cellHeight-1)
+				}
+			}
+			if c.Style.Attrs&cellbuf.StrikethroughAttr != 0 {
+				for px := origin.X; px < origin.X+cellWidth; px++ {
+					set(px, origin.Y+cellHeight/2)
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// cellColors returns the foreground and background colors c is drawn with.
+func cellColors(c *cellbuf.Cell) (fg, bg color.RGBA) {
+	fg, bg = defaultForeground, defaultBackground
+	if c == nil {
+		return fg, bg
+	}
+
+	if c.Style.Fg != nil {
+		fg = rgba(c.Style.Fg)
+	}
+	if c.Style.Bg != nil {
+		bg = rgba(c.Style.Bg)
+	}
+	if c.Style.Attrs&cellbuf.ReverseAttr != 0 {
+		fg, bg = bg, fg
+	}
+	return fg, bg
+}
+
+func rgba(c ansi.Color) color.RGBA {
+	return color.RGBAModel.Convert(c).(color.RGBA)
+}
@@ -0,0 +1,114 @@
+package record
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"testing"
+	"time"
+)
+
+func TestGIFTimeline(t *testing.T) {
+	frame := time.Second / 60
+	frames := []Frame{
+		{Screen: "a", Duration: time.Second},
+		{Screen: "b", Duration: frame},
+		{Screen: "c", Duration: frame},
+		{Screen: "d", Duration: frame},
+		{Screen: "e", Duration: time.Second},
+	}
+
+	got := gifTimeline(frames)
+	want := []gifFrame{
+		{screen: "a", delay: 100},
+		// b and d would each be shown for a hundredth of a second
+		{screen: "c", delay: 3},
+		{screen: "e", delay: 101},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("gifTimeline() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("gifTimeline()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWriteGIF(t *testing.T) {
+	frames := []Frame{
+		{Screen: "\x1b[31mab\x1b[0m\ncd", Duration: time.Second},
+		{Screen: "\x1b[31mab\x1b[0m\ncx", Duration: time.Second},
+		{Screen: "\x1b[31mab\x1b[0m\ncx\x1b_Ga=d\x1b\\", Duration: time.Second},
+	}
+
+	var out bytes.Buffer
+	if err := WriteGIF(&out, frames, 2, 2); err != nil {
+		t.Fatalf("WriteGIF() error = %v", err)
+	}
+
+	g, err := gif.DecodeAll(&out)
+	if err != nil {
+		t.Fatalf("gif.DecodeAll() error = %v", err)
+	}
+
+	if len(g.Image) != 2 {
+		t.Fatalf("got %d images, want the unchanged screen merged", len(g.Image))
+	}
+	if g.Delay[0] != 100 || g.Delay[1] != 200 {
+		t.Errorf("delays = %v, want [100 200]", g.Delay)
+	}
+
+	full := image.Rect(0, 0, 2*cellWidth, 2*cellHeight)
+	if g.Image[0].Bounds() != full {
+		t.Errorf("first image bounds = %v, want %v", g.Image[0].Bounds(), full)
+	}
+	changed := image.Rect(cellWidth, cellHeight, 2*cellWidth, 2*cellHeight)
+	if g.Image[1].Bounds() != changed {
+		t.Errorf("second image bounds = %v, want the changed cell %v", g.Image[1].Bounds(), changed)
+	}
+
+	// The red text is drawn in red
+	red := false
+	for y := range cellHeight {
+		for x := range cellWidth {
+			if r, g, b, _ := g.Image[0].At(x, y).RGBA(); r > 0 && g == 0 && b == 0 {
+				red = true
+			}
+		}
+	}
+	if !red {
+		t.Error("first image has no red pixel in the first cell")
+	}
+}
+
+func TestDrawGlyph(t *testing.T) {
+	draw := func(r rune) map[image.Point]bool {
+		pixels := map[image.Point]bool{}
+		drawGlyph(r, image.Point{}, false, func(x, y int) { pixels[image.Pt(x, y)] = true })
+		return pixels
+	}
+
+	if got := draw(' '); len(got) != 0 {
+		t.Errorf("space drew %d pixels", len(got))
+	}
+	if got := draw('█'); len(got) != cellWidth*cellHeight {
+		t.Errorf("full block drew %d pixels, want %d", len(got), cellWidth*cellHeight)
+	}
+
+	// A horizontal line crosses the cell through its middle
+	line := draw('─')
+	for x := range cellWidth {
+		if !line[image.Pt(x, cellHeight/2)] {
+			t.Errorf("horizontal line misses pixel %d", x)
+		}
+	}
+
+	if got := draw('A'); len(got) == 0 {
+		t.Error("A drew nothing")
+	}
+	if got := draw('日'); len(got) == 0 {
+		t.Error("a character missing from the font drew nothing")
+	}
+}
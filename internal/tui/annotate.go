@@ -0,0 +1,324 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	charmansi "github.com/charmbracelet/x/ansi"
+)
+
+const annotationsSuffix = ".annotations.json"
+
+// annotationColors are the colors strokes can be drawn with, cycled with a
+// key.
+var annotationColors = []string{"#FF5555", "#F1FA8C", "#50FA7B", "#8BE9FD", "#FF79C6"}
+
+// Stroke is a freehand line or a box drawn over a slide, in terminal cells.
+type Stroke struct {
+	Box    bool     `json:"box,omitempty"`
+	Color  string   `json:"color"`
+	Points [][2]int `json:"points"`
+}
+
+// Annotations are the strokes drawn over each slide during the session, and
+// the state of the annotation mode used to draw them.
+type Annotations struct {
+	active  bool
+	box     bool
+	color   int
+	drawing bool
+	// strokes are keyed by the position of the slide in the deck, starting
+	// at 0, skipped slides included.
+	strokes map[int][]Stroke
+}
+
+func NewAnnotations() Annotations {
+	return Annotations{strokes: map[int][]Stroke{}}
+}
+
+// AnnotationsFile returns the sidecar file annotations of presentation are
+// saved to.
+func AnnotationsFile(presentation string) string {
+	return filepath.Clean(presentation) + annotationsSuffix
+}
+
+// LoadAnnotations reads annotations saved by [Annotations.Save]. A missing
+// file is not an error.
+func LoadAnnotations(path string) (Annotations, error) {
+	a := NewAnnotations()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return a, nil
+	}
+	if err != nil {
+		return a, err
+	}
+
+	var saved map[string][]Stroke
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return a, err
+	}
+	for k, strokes := range saved {
+		i, err := strconv.Atoi(k)
+		if err != nil {
+			return a, err
+		}
+		a.strokes[i] = strokes
+	}
+
+	return a, nil
+}
+
+// Save writes the annotations of every slide to path as JSON.
+func (a Annotations) Save(path string) error {
+	saved := map[string][]Stroke{}
+	for i, strokes := range a.strokes {
+		if len(strokes) > 0 {
+			saved[strconv.Itoa(i)] = strokes
+		}
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (a Annotations) IsActive() bool {
+	return a.active
+}
+
+func (a Annotations) Toggle() Annotations {
+	a.active = !a.active
+	a.drawing = false
+	return a
+}
+
+// Clear removes the annotations of the slide at index.
+func (a Annotations) Clear(index int) Annotations {
+	delete(a.strokes, index)
+	return a
+}
+
+// Update draws with mouse events over the slide at index while the mode is
+// active, and reports whether msg was consumed.
+func (a Annotations) Update(msg tea.Msg, index int) (Annotations, bool) {
+	if !a.active {
+		return a, false
+	}
+
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		point := [2]int{msg.X, msg.Y}
+
+		switch {
+		case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+			if a.strokes == nil {
+				a.strokes = map[int][]Stroke{}
+			}
+			a.drawing = true
+			a.strokes[index] = append(a.strokes[index], Stroke{
+				Box:    a.box,
+				Color:  annotationColors[a.color],
+				Points: [][2]int{point},
+			})
+		case msg.Action == tea.MouseActionMotion && a.drawing:
+			strokes := a.strokes[index]
+			if len(strokes) == 0 {
+				break
+			}
+			last := &strokes[len(strokes)-1]
+			if last.Box {
+				last.Points = [][2]int{last.Points[0], point}
+			} else if last.Points[len(last.Points)-1] != point {
+				last.Points = append(last.Points, point)
+			}
+		case msg.Action == tea.MouseActionRelease:
+			a.drawing = false
+		}
+		return a, true
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			a.box = !a.box
+		case "c":
+			a.color = (a.color + 1) % len(annotationColors)
+		case "x":
+			a = a.Clear(index)
+		default:
+			return a, false
+		}
+		return a, true
+	}
+
+	return a, false
+}
+
+// Show composites the annotations of the slide at index over view, leaving
+// the cells they do not cover untouched.
+func (a Annotations) Show(view string, index int) string {
+	strokes := a.strokes[index]
+	if len(strokes) == 0 && !a.active {
+		return view
+	}
+
+	cells := map[[2]int]string{}
+	for _, stroke := range strokes {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(stroke.Color)).Bold(true)
+		for point, r := range stroke.cells() {
+			cells[point] = style.Render(string(r))
+		}
+	}
+
+	view = drawCells(view, cells)
+
+	if a.active {
+		view = placeOverlay(0, 0, a.status(), view)
+	}
+
+	return view
+}
+
+// status describes the annotation mode and its keys.
+func (a Annotations) status() string {
+	tool := "pen"
+	if a.box {
+		tool = "box"
+	}
+
+	swatch := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(annotationColors[a.color])).
+		Render("●")
+
+	return lipgloss.NewStyle().
+		Background(lipgloss.Color("#2A2A2A")).
+		Foreground(lipgloss.Color("#DDDDDD")).
+		Padding(0, 1).
+		Render("Annotating " + swatch + " " + tool + " · r: pen/box · c: color · x: clear · ctrl+s: save · d: done")
+}
+
+// cells returns the character drawn at each cell covered by the stroke.
+func (s Stroke) cells() map[[2]int]rune {
+	cells := map[[2]int]rune{}
+	if len(s.Points) == 0 {
+		return cells
+	}
+
+	if s.Box {
+		start, end := s.Points[0], s.Points[len(s.Points)-1]
+		x0, x1 := min(start[0], end[0]), max(start[0], end[0])
+		y0, y1 := min(start[1], end[1]), max(start[1], end[1])
+
+		for x := x0; x <= x1; x++ {
+			cells[[2]int{x, y0}] = '─'
+			cells[[2]int{x, y1}] = '─'
+		}
+		for y := y0; y <= y1; y++ {
+			cells[[2]int{x0, y}] = '│'
+			cells[[2]int{x1, y}] = '│'
+		}
+		cells[[2]int{x0, y0}] = '╭'
+		cells[[2]int{x1, y0}] = '╮'
+		cells[[2]int{x0, y1}] = '╰'
+		cells[[2]int{x1, y1}] = '╯'
+		return cells
+	}
+
+	cells[s.Points[0]] = '•'
+	for i := 1; i < len(s.Points); i++ {
+		for _, p := range line(s.Points[i-1], s.Points[i]) {
+			cells[p] = '•'
+		}
+	}
+	return cells
+}
+
+// line returns the cells between a and b, both included, using Bresenham's
+// algorithm.
+func line(a, b [2]int) [][2]int {
+	x0, y0, x1, y1 := a[0], a[1], b[0], b[1]
+
+	dx, sx := abs(x1-x0), 1
+	if x0 > x1 {
+		sx = -1
+	}
+	dy, sy := -abs(y1-y0), 1
+	if y0 > y1 {
+		sy = -1
+	}
+
+	var points [][2]int
+	err := dx + dy
+	for {
+		points = append(points, [2]int{x0, y0})
+		if x0 == x1 && y0 == y1 {
+			return points
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// drawCells replaces the cells of view at the given positions, each with a
+// single-width string.
+func drawCells(view string, cells map[[2]int]string) string {
+	if len(cells) == 0 {
+		return view
+	}
+
+	rows := map[int][]int{}
+	for p := range cells {
+		rows[p[1]] = append(rows[p[1]], p[0])
+	}
+
+	lines := strings.Split(view, "\n")
+	for y, xs := range rows {
+		if y < 0 || y >= len(lines) {
+			continue
+		}
+		slices.Sort(xs)
+
+		line := lines[y]
+		width := charmansi.StringWidth(line)
+
+		var b strings.Builder
+		pos := 0
+		for _, x := range xs {
+			if x < pos || x >= width {
+				continue
+			}
+			b.WriteString(charmansi.Cut(line, pos, x))
+			b.WriteString(cells[[2]int{x, y}])
+			pos = x + 1
+		}
+		b.WriteString(charmansi.TruncateLeft(line, pos, ""))
+
+		lines[y] = b.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
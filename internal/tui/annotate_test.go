@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func drag(a Annotations, index int, points ...[2]int) Annotations {
+	for i, p := range points {
+		action := tea.MouseActionMotion
+		if i == 0 {
+			action = tea.MouseActionPress
+		}
+		a, _ = a.Update(tea.MouseMsg{X: p[0], Y: p[1], Action: action, Button: tea.MouseButtonLeft}, index)
+	}
+	a, _ = a.Update(tea.MouseMsg{Action: tea.MouseActionRelease}, index)
+	return a
+}
+
+func TestAnnotations_Draw(t *testing.T) {
+	view := "......\n......\n......"
+
+	a := NewAnnotations()
+	if _, handled := a.Update(tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}, 0); handled {
+		t.Fatal("inactive annotations consumed a mouse event")
+	}
+
+	a = a.Toggle()
+	a = drag(a, 0, [2]int{1, 1}, [2]int{4, 1})
+	a = a.Toggle()
+
+	got := strings.Split(ansi.Strip(a.Show(view, 0)), "\n")
+	if got[1] != ".••••." {
+		t.Errorf("stroke line = %q, want %q", got[1], ".••••.")
+	}
+	if got[0] != "......" || got[2] != "......" {
+		t.Errorf("stroke changed other lines: %q", got)
+	}
+
+	if got := a.Show(view, 1); got != view {
+		t.Errorf("annotations of another slide shown: %q", got)
+	}
+}
+
+func TestAnnotations_ZeroValue(t *testing.T) {
+	var a Annotations
+	a = drag(a.Toggle(), 0, [2]int{0, 0}, [2]int{1, 0})
+	a = a.Toggle()
+
+	if got := ansi.Strip(a.Show("...", 0)); got != "••." {
+		t.Errorf("stroke = %q, want %q", got, "••.")
+	}
+}
+
+func TestAnnotations_Box(t *testing.T) {
+	view := "......\n......\n......\n......"
+
+	a := NewAnnotations().Toggle()
+	a, _ = a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}, 0)
+	a = drag(a, 0, [2]int{1, 0}, [2]int{2, 2}, [2]int{4, 2})
+	a = a.Toggle()
+
+	want := []string{
+		".╭──╮.",
+		".│..│.",
+		".╰──╯.",
+		"......",
+	}
+	got := strings.Split(ansi.Strip(a.Show(view, 0)), "\n")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("box = %q, want %q", got, want)
+	}
+}
+
+func TestAnnotations_Clear(t *testing.T) {
+	view := "....\n...."
+
+	a := drag(NewAnnotations().Toggle(), 0, [2]int{0, 0}, [2]int{3, 0})
+	a = drag(a, 1, [2]int{0, 1})
+
+	a, handled := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}, 0)
+	if !handled {
+		t.Fatal("clear key not handled")
+	}
+	a = a.Toggle()
+
+	if got := a.Show(view, 0); got != view {
+		t.Errorf("cleared slide still annotated: %q", got)
+	}
+	if got := a.Show(view, 1); got == view {
+		t.Error("clearing a slide removed the annotations of another")
+	}
+}
+
+func TestAnnotations_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "talk.md"+annotationsSuffix)
+
+	if a, err := LoadAnnotations(path); err != nil || len(a.strokes) != 0 {
+		t.Fatalf("LoadAnnotations(missing) = %v, %v", a.strokes, err)
+	}
+
+	a := drag(NewAnnotations().Toggle(), 2, [2]int{0, 0}, [2]int{2, 1})
+	if err := a.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadAnnotations(path)
+	if err != nil {
+		t.Fatalf("LoadAnnotations() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.strokes, a.strokes) {
+		t.Errorf("LoadAnnotations() = %v, want %v", loaded.strokes, a.strokes)
+	}
+}
+
+func TestLine(t *testing.T) {
+	got := line([2]int{0, 0}, [2]int{3, 1})
+	want := [][2]int{{0, 0}, {1, 0}, {2, 1}, {3, 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("line() = %v, want %v", got, want)
+	}
+}
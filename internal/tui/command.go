@@ -25,16 +25,31 @@ var (
 			PaddingBottom(2)
 	quitTextStyle = lipgloss.NewStyle().
 			Margin(0, 0, 0, 2)
+	sectionStyle = lipgloss.NewStyle().
+			Bold(true)
+	skippedItemStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("240"))
 )
 
 type SlideItem struct {
 	slide  *Slide
 	title  string
 	number int
+	// skipped slides are listed without a number
+	skipped bool
 }
 
 func (s SlideItem) FilterValue() string { return s.title }
 
+// SectionItem heads the slides of a section in the palette. Choosing it goes
+// to the first slide of the section.
+type SectionItem struct {
+	slide *Slide
+	title string
+}
+
+func (s SectionItem) FilterValue() string { return s.title }
+
 type itemDelegate struct{}
 
 func (d itemDelegate) Height() int                             { return 1 }
@@ -49,13 +64,24 @@ func renderItem(str string, isSelected bool) string {
 }
 
 func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
-	i, ok := listItem.(SlideItem)
-	if !ok {
-		return
+	switch i := listItem.(type) {
+	case SlideItem:
+		str := fmt.Sprintf("%d. %s", i.number, i.title)
+		if i.skipped {
+			str = fmt.Sprintf("⊘  %s (hidden)", i.title)
+			if index != m.Index() {
+				fmt.Fprint(w, itemStyle.Inherit(skippedItemStyle).Render(str))
+				return
+			}
+		}
+		fmt.Fprint(w, renderItem(str, index == m.Index()))
+	case SectionItem:
+		if index == m.Index() {
+			fmt.Fprint(w, selectedItemStyle.Inherit(sectionStyle).Render("> "+i.title))
+			return
+		}
+		fmt.Fprint(w, sectionStyle.Render(i.title))
 	}
-
-	str := fmt.Sprintf("%d. %s", i.number, i.title)
-	fmt.Fprint(w, renderItem(str, index == m.Index()))
 }
 
 type Command struct {
@@ -70,21 +96,33 @@ func NewCommand(rootSlide *Slide) Command {
 
 	current := rootSlide
 	slideNumber := 1
+	section := ""
 
 	for current != nil {
+		if current.Properties.Section != section {
+			section = current.Properties.Section
+			if section != "" {
+				items = append(items, SectionItem{slide: current, title: section})
+			}
+		}
+
 		title := current.Properties.Title
 		if title == "" {
 			title = fmt.Sprintf("#%d", slideNumber)
 		}
 
 		items = append(items, SlideItem{
-			slide:  current,
-			title:  title,
-			number: slideNumber,
+			slide:   current,
+			title:   title,
+			number:  slideNumber,
+			skipped: current.Skipped,
 		})
 
+		// Skipped slides do not count, like when navigating
+		if !current.Skipped {
+			slideNumber++
+		}
 		current = current.Next
-		slideNumber++
 	}
 
 	const modalWidth = 90
@@ -140,7 +178,10 @@ func (m Command) Update(msg tea.Msg) (Command, tea.Cmd) {
 			}
 		case "enter":
 			if item := m.list.SelectedItem(); item != nil {
-				if i, ok := item.(SlideItem); ok {
+				switch i := item.(type) {
+				case SlideItem:
+					m.choice = i.slide
+				case SectionItem:
 					m.choice = i.slide
 				}
 			}
@@ -0,0 +1,269 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/harmonica"
+	charmansi "github.com/charmbracelet/x/ansi"
+
+	"github.com/museslabs/kyma/internal/markdown"
+	"github.com/museslabs/kyma/internal/tui/transitions"
+)
+
+// effect draws a block during its entrance animation.
+type effect struct {
+	// frequency is the one of the spring taking progress from 0 to 1.
+	frequency float64
+	view      func(lines []string, span blockSpan, code bool, progress float64)
+}
+
+var effects = map[string]effect{
+	"typewriter": {frequency: 2.0, view: typewriter},
+	"slideIn":    {frequency: 4.0, view: slideIn},
+}
+
+// checkAnimations returns an error if one of animations has no effect.
+func checkAnimations(animations []markdown.Animation) error {
+	for _, a := range animations {
+		if _, ok := effects[a.Name]; !ok {
+			return fmt.Errorf(
+				"unknown animation %q, available animations are: %s",
+				a.Name,
+				strings.Join(slices.Sorted(maps.Keys(effects)), ", "),
+			)
+		}
+	}
+	return nil
+}
+
+// entrance animates the blocks of a slide in one after the other when the
+// slide is shown. Blocks before current are shown, those after it hidden.
+type entrance struct {
+	animations []markdown.Animation
+	current    int
+	spring     harmonica.Spring
+	progress   float64
+	vel        float64
+}
+
+// newEntrance returns the entrance of a slide whose blocks have animations,
+// already over under reduced motion.
+func newEntrance(animations []markdown.Animation) entrance {
+	e := entrance{animations: animations}
+	switch transitions.CurrentReducedMotion() {
+	case transitions.ReducedMotionFade, transitions.ReducedMotionNone:
+		e.current = len(animations)
+	}
+	return e.next(e.current)
+}
+
+// next moves on to the block at i.
+func (e entrance) next(i int) entrance {
+	e.current = i
+	e.progress, e.vel = 0, 0
+	if e.Animating() {
+		const damping = 0.9
+		e.spring = harmonica.NewSpring(
+			harmonica.FPS(transitions.Fps),
+			effects[e.animations[i].Name].frequency,
+			damping,
+		)
+	}
+	return e
+}
+
+// finish shows every block right away.
+func (e entrance) finish() entrance {
+	return e.next(len(e.animations))
+}
+
+func (e entrance) Animating() bool {
+	return e.current < len(e.animations)
+}
+
+func (e entrance) Update() (entrance, tea.Cmd) {
+	if !e.Animating() {
+		return e, nil
+	}
+
+	e.progress, e.vel = e.spring.Update(e.progress, e.vel, 1)
+	if e.progress >= 1 {
+		e = e.next(e.current + 1)
+		if !e.Animating() {
+			return e, nil
+		}
+	}
+
+	return e, transitions.Animate(time.Duration(transitions.Fps))
+}
+
+// View draws out, rendered by [markdown.Renderer.RenderAnimated], with the
+// blocks in their current state.
+func (e entrance) View(out string) string {
+	lines := strings.Split(out, "\n")
+	spans := locateBlocks(lines)
+
+	for i, span := range spans {
+		if i >= len(e.animations) {
+			break
+		}
+
+		switch {
+		case i < e.current:
+		case i > e.current:
+			typewriter(lines, span, e.animations[i].Code, 0)
+		default:
+			progress := math.Min(math.Max(e.progress, 0), 1)
+			effects[e.animations[i].Name].view(lines, span, e.animations[i].Code, progress)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// blockSpan is where a block is displayed, from column start of line first
+// to column end of line last.
+type blockSpan struct {
+	first, start int
+	last, end    int
+}
+
+// columns returns the columns of line l the block covers: from where it or
+// the text of the line starts, to where it or the text of the line ends.
+func (s blockSpan) columns(lines []string, l int) (start, end int) {
+	start, end = textColumns(lines[l])
+	if l == s.first {
+		start = s.start
+	}
+	if l == s.last {
+		end = s.end
+	}
+	return start, max(start, end)
+}
+
+// textColumns returns the columns line has text between, leaving out the
+// spaces around it.
+func textColumns(line string) (start, end int) {
+	text := charmansi.Strip(line)
+	start = charmansi.StringWidth(text) - charmansi.StringWidth(strings.TrimLeft(text, " "))
+	end = charmansi.StringWidth(strings.TrimRight(text, " "))
+	return start, end
+}
+
+// locateBlocks finds the blocks wrapped in markers in lines, in order, and
+// strips the markers.
+func locateBlocks(lines []string) []blockSpan {
+	var spans []blockSpan
+	open := false
+	for l, line := range lines {
+		for {
+			i := strings.Index(line, markdown.AnimationStart)
+			j := strings.Index(line, markdown.AnimationEnd)
+			if open && j >= 0 {
+				spans[len(spans)-1].last = l
+				spans[len(spans)-1].end = charmansi.StringWidth(line[:j])
+				line = line[:j] + line[j+len(markdown.AnimationEnd):]
+				open = false
+				continue
+			}
+			if !open && i >= 0 {
+				col := charmansi.StringWidth(line[:i])
+				spans = append(spans, blockSpan{first: l, start: col, last: l, end: col})
+				line = line[:i] + line[i+len(markdown.AnimationStart):]
+				open = true
+				continue
+			}
+			break
+		}
+		lines[l] = line
+	}
+	return spans
+}
+
+// typewriter types the block out character by character, and code blocks
+// line by line, line numbers included.
+func typewriter(lines []string, span blockSpan, code bool, progress float64) {
+	if code {
+		shown := span.first + int(math.Round(progress*float64(span.last-span.first+1)))
+		for l := shown; l <= span.last; l++ {
+			start, _ := textColumns(lines[l])
+			_, end := span.columns(lines, l)
+			lines[l] = editCells(lines[l], start, end, blankCell)
+		}
+		return
+	}
+
+	total := 0
+	for l := span.first; l <= span.last; l++ {
+		start, end := span.columns(lines, l)
+		total += end - start
+	}
+
+	shown := int(math.Round(progress * float64(total)))
+	for l := span.first; l <= span.last; l++ {
+		start, end := span.columns(lines, l)
+		lines[l] = editCells(lines[l], start+max(min(shown, end-start), 0), end, blankCell)
+		shown -= end - start
+	}
+}
+
+// slideIn slides the block in from its left edge.
+func slideIn(lines []string, span blockSpan, _ bool, progress float64) {
+	width := 0
+	for l := span.first; l <= span.last; l++ {
+		start, end := span.columns(lines, l)
+		width = max(width, end-start)
+	}
+
+	shift := int(math.Round((1 - progress) * float64(width)))
+	for l := span.first; l <= span.last; l++ {
+		start, end := span.columns(lines, l)
+		by := min(shift, end-start)
+		lines[l] = editCells(lines[l], start, end, func(col int, cell string, width int) string {
+			// Cells move left by the shift, the ones past the left edge
+			// disappearing and spaces taking the place of the last ones
+			if col < start+by {
+				cell = ""
+			}
+			if col+width >= end {
+				cell += strings.Repeat(" ", by)
+			}
+			return cell
+		})
+	}
+}
+
+func blankCell(_ int, _ string, width int) string {
+	return strings.Repeat(" ", width)
+}
+
+// editCells replaces the cells of line from column start to end with what
+// edit returns for them, keeping the escape sequences styling them.
+func editCells(line string, start, end int, edit func(col int, cell string, width int) string) string {
+	if start >= end {
+		return line
+	}
+
+	var b strings.Builder
+	var state byte
+	col := 0
+	for len(line) > 0 {
+		seq, width, n, newState := charmansi.DecodeSequence(line, state, nil)
+		state = newState
+		line = line[n:]
+
+		if width > 0 && col < end && col+width > start {
+			b.WriteString(edit(col, seq, width))
+		} else {
+			b.WriteString(seq)
+		}
+		col += width
+	}
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	charmansi "github.com/charmbracelet/x/ansi"
+
+	"github.com/museslabs/kyma/internal/config"
+	"github.com/museslabs/kyma/internal/markdown"
+	"github.com/museslabs/kyma/internal/tui/transitions"
+)
+
+func TestTypewriter(t *testing.T) {
+	lines := []string{"  • " + markdown.AnimationStart + "\x1b[1mhello\x1b[0m world" + markdown.AnimationEnd + "  "}
+	spans := locateBlocks(lines)
+	if len(spans) != 1 {
+		t.Fatalf("locateBlocks() found %d blocks, want 1", len(spans))
+	}
+
+	typewriter(lines, spans[0], false, 0.5)
+	if got, want := charmansi.Strip(lines[0]), "  • hello        "; got != want {
+		t.Errorf("typewriter() = %q, want %q", got, want)
+	}
+	if !strings.Contains(lines[0], "\x1b[1m") {
+		t.Errorf("typewriter() = %q, want the styles kept", lines[0])
+	}
+}
+
+func TestTypewriter_Code(t *testing.T) {
+	lines := []string{
+		" 1 " + markdown.AnimationStart + "func main() {",
+		" 2     fmt.Println()",
+		" 3 }" + markdown.AnimationEnd,
+	}
+	spans := locateBlocks(lines)
+
+	typewriter(lines, spans[0], true, 0.4)
+	want := []string{" 1 func main() {", strings.Repeat(" ", 20), "    "}
+	for i := range want {
+		if got := charmansi.Strip(lines[i]); got != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestSlideIn(t *testing.T) {
+	tests := []struct {
+		progress float64
+		want     string
+	}{
+		{progress: 0, want: "- " + "      " + " |"},
+		{progress: 0.5, want: "- " + "de    " + " |"},
+		{progress: 1, want: "- " + "slide " + " |"},
+	}
+
+	for _, tt := range tests {
+		lines := []string{"- " + markdown.AnimationStart + "slide " + markdown.AnimationEnd + " |"}
+		spans := locateBlocks(lines)
+
+		slideIn(lines, spans[0], false, tt.progress)
+		if got := lines[0]; got != tt.want {
+			t.Errorf("slideIn() at %v = %q, want %q", tt.progress, got, tt.want)
+		}
+	}
+}
+
+func TestSlide_Entrance(t *testing.T) {
+	slide, err := NewSlide("# Title {animate=typewriter}\n\n- item {animate=slideIn}\n", "", config.Properties{})
+	if err != nil {
+		t.Fatalf("NewSlide() error = %v", err)
+	}
+
+	view := func() string {
+		return charmansi.Strip(slide.View(false, 0))
+	}
+
+	slide.enter(false)
+	if got := view(); strings.Contains(got, "Title") || strings.Contains(got, "item") {
+		t.Fatalf("View() = %q, want the blocks hidden before they animate in", got)
+	}
+
+	for i := 0; slide.entrance.Animating(); i++ {
+		if i > 10*transitions.Fps {
+			t.Fatal("entrance animations never end")
+		}
+		slide.Update()
+	}
+	if got := view(); !strings.Contains(got, "Title") || !strings.Contains(got, "item") {
+		t.Errorf("View() = %q, want the blocks shown once they animated in", got)
+	}
+
+	slide.enter(true)
+	if slide.entrance.Animating() {
+		t.Error("entrance animations play when reaching the slide backwards")
+	}
+}
+
+func TestSlide_UnknownAnimation(t *testing.T) {
+	_, err := NewSlide("# Title {animate=spin}", "", config.Properties{})
+	if err == nil || !strings.Contains(err.Error(), "typewriter") {
+		t.Errorf("NewSlide() error = %v, want one listing the available animations", err)
+	}
+}
+
+func TestEntrance_ReducedMotion(t *testing.T) {
+	defer transitions.SetReducedMotion(transitions.CurrentReducedMotion())
+	transitions.SetReducedMotion(transitions.ReducedMotionNone)
+
+	e := newEntrance([]markdown.Animation{{Name: "typewriter"}})
+	if e.Animating() {
+		t.Error("entrance animations play under reduced motion")
+	}
+}
@@ -0,0 +1,56 @@
+package tui
+
+// History is the jump list of the slides visited by jumping to them rather
+// than moving to the next or previous one, by position in the deck, so that
+// it survives reloads.
+type History struct {
+	entries []int
+	// index is the entry going back returns to next, or len(entries) when
+	// not currently going through the list.
+	index int
+}
+
+// Push records from, the slide being jumped away from. Entries that were
+// gone back past are dropped, like in vim.
+func (h History) Push(from int) History {
+	entries := append([]int(nil), h.entries[:h.index]...)
+	if len(entries) == 0 || entries[len(entries)-1] != from {
+		entries = append(entries, from)
+	}
+	h.entries = entries
+	h.index = len(entries)
+	return h
+}
+
+// Back returns the slide visited before, current being the slide the
+// presenter is on.
+func (h History) Back(current int) (History, int, bool) {
+	entries, index := h.entries, h.index
+
+	// Remember where going back started to be able to go forward to it,
+	// unless it was the last slide jumped away from
+	if index == len(entries) {
+		if index > 0 && entries[index-1] == current {
+			index--
+		} else {
+			entries = append(append([]int(nil), entries...), current)
+		}
+	}
+
+	if index == 0 {
+		return h, 0, false
+	}
+
+	h.entries, h.index = entries, index-1
+	return h, h.entries[h.index], true
+}
+
+// Forward returns the slide gone back from.
+func (h History) Forward() (History, int, bool) {
+	if h.index+1 >= len(h.entries) {
+		return h, 0, false
+	}
+
+	h.index++
+	return h, h.entries[h.index], true
+}
@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/museslabs/kyma/internal/tui/transitions"
+)
+
+func TestHistory(t *testing.T) {
+	var h History
+
+	if _, _, ok := h.Back(0); ok {
+		t.Fatal("Back() on an empty history succeeded")
+	}
+
+	// 0 -> 3 -> 5
+	h = h.Push(0).Push(3)
+
+	steps := []struct {
+		back    bool
+		current int
+		want    int
+		ok      bool
+	}{
+		{back: true, current: 5, want: 3, ok: true},
+		{back: true, current: 3, want: 0, ok: true},
+		{back: true, current: 0, ok: false},
+		{back: false, want: 3, ok: true},
+		{back: false, want: 5, ok: true},
+		{back: false, ok: false},
+	}
+
+	for i, step := range steps {
+		var got int
+		var ok bool
+		if step.back {
+			h, got, ok = h.Back(step.current)
+		} else {
+			h, got, ok = h.Forward()
+		}
+		if ok != step.ok || ok && got != step.want {
+			t.Errorf("step %d = %d, %v, want %d, %v", i, got, ok, step.want, step.ok)
+		}
+	}
+}
+
+func TestHistory_PushDropsForward(t *testing.T) {
+	h := History{}.Push(0).Push(3)
+
+	h, _, _ = h.Back(5)
+	h, _, _ = h.Back(3)
+	// Back on 0, jump to 7
+	h = h.Push(0)
+
+	if _, _, ok := h.Forward(); ok {
+		t.Error("Forward() succeeded after a new jump")
+	}
+	h, got, ok := h.Back(7)
+	if !ok || got != 0 {
+		t.Errorf("Back() = %d, %v, want 0, true", got, ok)
+	}
+}
+
+func TestModel_HistoryAndMarks(t *testing.T) {
+	m, slides := screenModel()
+
+	m = press(m, "m")
+	m = press(m, "a")
+	m = press(m, "$")
+	if m.slide != slides[2] {
+		t.Fatal("bottom did not move to the last slide")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	m = updated.(model)
+	if m.slide != slides[0] {
+		t.Errorf("ctrl+o did not go back to the first slide")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(model)
+	if m.slide != slides[2] {
+		t.Errorf("ctrl+i did not go forward to the last slide")
+	}
+
+	m = press(m, "'")
+	m = press(m, "a")
+	if m.slide != slides[0] {
+		t.Errorf("jumping to mark a did not go to the first slide")
+	}
+
+	m = press(m, "'")
+	m = press(m, "z")
+	if m.slide != slides[0] {
+		t.Errorf("jumping to an unset mark moved")
+	}
+}
+
+func TestModel_JumpTransition(t *testing.T) {
+	swipe, err := transitions.Get("swipeLeft", transitions.Params{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	m, slides := screenModel()
+	m.width, m.height = 80, 24
+	m.deck.JumpTransition = swipe
+
+	m = press(m, "$")
+	if m.slide != slides[2] || m.slide.from != slides[0] {
+		t.Fatal("bottom did not transition from the first slide")
+	}
+	if m.slide.ActiveTransition.Name() != "swipeLeft" || !m.slide.ActiveTransition.Animating() {
+		t.Errorf("jump played %s, want swipeLeft", m.slide.ActiveTransition.Name())
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("0")})
+	m = updated.(model)
+	if cmd != nil {
+		t.Error("jump mid-transition started a second loop of frames")
+	}
+	if m.slide.ActiveTransition.Name() != "swipeRight" {
+		t.Errorf("jump back played %s, want swipeRight", m.slide.ActiveTransition.Name())
+	}
+	if slides[2].ActiveTransition != nil {
+		t.Error("slide left kept its transition")
+	}
+}
@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	charmansi "github.com/charmbracelet/x/ansi"
+
+	"github.com/museslabs/kyma/internal/markdown"
+)
+
+// hintLabels are the keys used to pick a link, home row first.
+const hintLabels = "asdfghjklqwertyuiopzxcvbnm"
+
+var hintStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("#000000")).
+	Background(lipgloss.Color("#F1FA8C"))
+
+// linkSpan is where a link is displayed on screen.
+type linkSpan struct {
+	link  markdown.Link
+	x, y  int
+	width int
+}
+
+func (s linkSpan) contains(x, y int) bool {
+	return y == s.y && x >= s.x && x < s.x+s.width
+}
+
+// locateLinks finds where the internal links are displayed in view by
+// searching for their text, in order. Links whose text is wrapped over
+// several lines are not found.
+func locateLinks(view string, links []markdown.Link) []linkSpan {
+	lines := strings.Split(charmansi.Strip(view), "\n")
+
+	var spans []linkSpan
+	line, offset := 0, 0
+	for _, link := range links {
+		if !link.Internal() {
+			continue
+		}
+
+		for l := line; l < len(lines); l++ {
+			start := 0
+			if l == line {
+				start = offset
+			}
+
+			i := strings.Index(lines[l][start:], link.Text)
+			if i < 0 {
+				continue
+			}
+
+			spans = append(spans, linkSpan{
+				link:  link,
+				x:     charmansi.StringWidth(lines[l][:start+i]),
+				y:     l,
+				width: charmansi.StringWidth(link.Text),
+			})
+			line, offset = l, start+i+len(link.Text)
+			break
+		}
+	}
+
+	return spans
+}
+
+// linkAt returns the internal link displayed at x, y in view.
+func linkAt(view string, links []markdown.Link, x, y int) (markdown.Link, bool) {
+	for _, span := range locateLinks(view, links) {
+		if span.contains(x, y) {
+			return span.link, true
+		}
+	}
+	return markdown.Link{}, false
+}
+
+// LinkHints labels the links of the current slide with a key each, so that
+// they can be followed from the keyboard.
+type LinkHints struct {
+	spans    []linkSpan
+	choice   *markdown.Link
+	quitting bool
+}
+
+func NewLinkHints(view string, links []markdown.Link) LinkHints {
+	spans := locateLinks(view, links)
+	if len(spans) > len(hintLabels) {
+		spans = spans[:len(hintLabels)]
+	}
+	return LinkHints{spans: spans}
+}
+
+func (h LinkHints) Update(msg tea.Msg) (LinkHints, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return h, nil
+	}
+
+	// Any key other than a label cancels
+	h.quitting = true
+	if keyMsg.Type != tea.KeyRunes || len(keyMsg.Runes) != 1 {
+		return h, nil
+	}
+	if i := strings.IndexRune(hintLabels, keyMsg.Runes[0]); i >= 0 && i < len(h.spans) {
+		h.choice = &h.spans[i].link
+	}
+
+	return h, nil
+}
+
+// Show draws the label of every link over slideView.
+func (h LinkHints) Show(slideView string) string {
+	view := slideView
+	for i, span := range h.spans {
+		view = placeOverlay(span.x, span.y, hintStyle.Render(hintLabels[i:i+1]), view)
+	}
+	return view
+}
+
+// Choice returns the link picked, if any.
+func (h LinkHints) Choice() (markdown.Link, bool) {
+	if h.choice == nil {
+		return markdown.Link{}, false
+	}
+	return *h.choice, true
+}
+
+func (h LinkHints) Quitting() bool {
+	return h.quitting
+}
+
+func (h LinkHints) IsShowing() bool {
+	return !h.quitting && len(h.spans) > 0
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/museslabs/kyma/internal/config"
+	"github.com/museslabs/kyma/internal/markdown"
+)
+
+func TestLocateLinks(t *testing.T) {
+	view := "\x1b[1m  Intro\x1b[0m\n" +
+		"  see \x1b[4mdemo\x1b[0m or demo again\n" +
+		"  back to the start\n"
+
+	links := []markdown.Link{
+		{Text: "demo", Target: "#demo"},
+		{Text: "demo", Target: "slide:3"},
+		{Text: "site", Target: "https://example.com"},
+		{Text: "the start", Target: "slide:1"},
+		{Text: "missing", Target: "#missing"},
+	}
+
+	want := []linkSpan{
+		{link: links[0], x: 6, y: 1, width: 4},
+		{link: links[1], x: 14, y: 1, width: 4},
+		{link: links[3], x: 10, y: 2, width: 9},
+	}
+
+	got := locateLinks(view, links)
+	if len(got) != len(want) {
+		t.Fatalf("locateLinks() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("locateLinks()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if link, ok := linkAt(view, links, 12, 2); !ok || link.Target != "slide:1" {
+		t.Errorf("linkAt() = %v, %v, want slide:1", link, ok)
+	}
+	if _, ok := linkAt(view, links, 2, 2); ok {
+		t.Error("linkAt() outside of any link should find nothing")
+	}
+}
+
+func TestLinkHints(t *testing.T) {
+	view := "one two\n"
+	links := []markdown.Link{
+		{Text: "one", Target: "#one"},
+		{Text: "two", Target: "#two"},
+	}
+
+	hints := NewLinkHints(view, links)
+	if !hints.IsShowing() {
+		t.Fatal("hints should be showing")
+	}
+
+	picked, _ := hints.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if link, ok := picked.Choice(); !ok || link.Target != "#two" {
+		t.Errorf("Choice() = %v, %v, want #two", link, ok)
+	}
+
+	cancelled, _ := hints.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := cancelled.Choice(); ok || !cancelled.Quitting() {
+		t.Error("esc should cancel without a choice")
+	}
+
+	if NewLinkHints(view, nil).IsShowing() {
+		t.Error("hints without links should not show")
+	}
+}
+
+func TestSlide_Resolve(t *testing.T) {
+	slides := linkSlides("", "", "", "")
+	slides[0].Data = "# Welcome\n"
+	slides[1].Properties = config.Properties{Title: "Live Demo!"}
+	slides[2].Skipped = true
+	slides[3].Properties = config.Properties{ID: "end"}
+
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{target: "#welcome", want: 0},
+		{target: "#live-demo", want: 1},
+		{target: "#end", want: 3},
+		{target: "slide:1", want: 0},
+		{target: "slide:3", want: 3},
+		{target: "slide:4", want: -1},
+		{target: "slide:x", want: -1},
+		{target: "#nothing", want: -1},
+		{target: "#", want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			got := slides[2].Resolve(tt.target)
+			if tt.want < 0 {
+				if got != nil {
+					t.Errorf("Resolve(%q) = %v, want nil", tt.target, got)
+				}
+				return
+			}
+			if got != slides[tt.want] {
+				t.Errorf("Resolve(%q) did not return slide %d", tt.target, tt.want)
+			}
+		})
+	}
+}
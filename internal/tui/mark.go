@@ -0,0 +1,105 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Mark reads the letter of a vim-style mark, either to set it on the current
+// slide or to jump to the slide it was set on.
+type Mark struct {
+	set      bool
+	letter   rune
+	quitting bool
+	showing  bool
+}
+
+func NewMark(set bool) Mark {
+	return Mark{set: set}
+}
+
+func (m Mark) Update(msg tea.Msg) (Mark, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	// Any key other than a letter cancels
+	m.quitting = true
+	m.showing = false
+	if keyMsg.Type == tea.KeyRunes && len(keyMsg.Runes) == 1 {
+		if r := keyMsg.Runes[0]; r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			m.letter = r
+		}
+	}
+
+	return m, nil
+}
+
+func (m Mark) Show(slideView string, width, height int) string {
+	prompt := "'"
+	action := "• jump to mark"
+	if m.set {
+		prompt = "m"
+		action = "• set mark"
+	}
+
+	statusContent := lipgloss.NewStyle().
+		Background(lipgloss.Color("#3C3C3C")).
+		Foreground(lipgloss.Color("#DDDDDD")).
+		Padding(0, 1).
+		Render(prompt + "_")
+
+	statusWidth := lipgloss.Width(statusContent)
+	statusX := (width - statusWidth) / 2
+	statusY := height - 1
+
+	letterHelp := lipgloss.JoinHorizontal(
+		lipgloss.Center,
+		mutedStyle.Render("a-z"),
+		" ",
+		veryMutedStyle.Render(action),
+	)
+
+	escHelp := lipgloss.JoinHorizontal(
+		lipgloss.Center,
+		mutedStyle.Render("esc"),
+		" ",
+		veryMutedStyle.Render("• cancel"),
+	)
+
+	helpText := lipgloss.JoinHorizontal(lipgloss.Center, letterHelp, "  ", escHelp)
+	helpWidth := lipgloss.Width(helpText)
+	helpX := (width - helpWidth) / 2
+	helpY := height - 3
+
+	viewWithStatus := placeOverlay(statusX, statusY, statusContent, slideView)
+	return placeOverlay(helpX, helpY, helpText, viewWithStatus)
+}
+
+func (m Mark) IsShowing() bool {
+	return m.showing
+}
+
+func (m Mark) SetShowing(showing bool) Mark {
+	m.showing = showing
+	if showing {
+		m.letter = 0
+		m.quitting = false
+	}
+	return m
+}
+
+func (m Mark) Quitting() bool {
+	return m.quitting
+}
+
+// Letter returns the letter typed, or 0 if cancelled.
+func (m Mark) Letter() rune {
+	return m.letter
+}
+
+// Setting reports whether the mark is set rather than jumped to.
+func (m Mark) Setting() bool {
+	return m.set
+}
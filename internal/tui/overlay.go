@@ -5,8 +5,6 @@ import (
 	"strings"
 
 	charmansi "github.com/charmbracelet/x/ansi"
-	ansi "github.com/muesli/reflow/ansi"
-	"github.com/muesli/reflow/truncate"
 	"github.com/muesli/termenv"
 )
 
@@ -68,8 +66,6 @@ func placeOverlay(x, y int, fg, bg string, opts ...WhitespaceOption) string {
 
 	var b strings.Builder
 
-	b.WriteString("\x1b_Ga=d\x1b\\")
-
 	for i, bgLine := range bgLines {
 		if i > 0 {
 			b.WriteByte('\n')
@@ -81,8 +77,8 @@ func placeOverlay(x, y int, fg, bg string, opts ...WhitespaceOption) string {
 
 		pos := 0
 		if x > 0 {
-			left := truncate.String(bgLine, uint(x))
-			pos = ansi.PrintableRuneWidth(left)
+			left := charmansi.Truncate(bgLine, x, "")
+			pos = charmansi.StringWidth(left)
 			b.WriteString(left)
 			if pos < x {
 				b.WriteString(ws.render(x - pos))
@@ -92,11 +88,11 @@ func placeOverlay(x, y int, fg, bg string, opts ...WhitespaceOption) string {
 
 		fgLine := fgLines[i-y]
 		b.WriteString(fgLine)
-		pos += ansi.PrintableRuneWidth(fgLine)
+		pos += charmansi.StringWidth(fgLine)
 
 		right := charmansi.TruncateLeft(bgLine, pos, "")
-		bgWidth = ansi.PrintableRuneWidth(bgLine)
-		rightWidth := ansi.PrintableRuneWidth(right)
+		bgWidth = charmansi.StringWidth(bgLine)
+		rightWidth := charmansi.StringWidth(right)
 		if rightWidth <= bgWidth-pos {
 			b.WriteString(ws.render(bgWidth - rightWidth - pos))
 		}
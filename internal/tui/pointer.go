@@ -0,0 +1,22 @@
+package tui
+
+import "github.com/charmbracelet/lipgloss"
+
+var pointerStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("#FF5555"))
+
+// pointer is a laser pointer following the mouse over the slide, for drawing
+// the audience's attention to a part of it.
+type pointer struct {
+	visible bool
+	x, y    int
+}
+
+// Show draws the pointer over view at the last known mouse position.
+func (p pointer) Show(view string) string {
+	if !p.visible {
+		return view
+	}
+	return placeOverlay(p.x, p.y, pointerStyle.Render("●"), view)
+}
@@ -0,0 +1,25 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestPointer_Show(t *testing.T) {
+	view := "....\n....\n...."
+
+	if got := (pointer{x: 1, y: 1}).Show(view); got != view {
+		t.Errorf("hidden pointer changed the view: %q", got)
+	}
+
+	got := ansi.Strip((pointer{visible: true, x: 2, y: 1}).Show(view))
+	lines := strings.Split(got, "\n")
+	if lines[1] != "..●." {
+		t.Errorf("pointer line = %q, want %q", lines[1], "..●.")
+	}
+	if lines[0] != "...." || lines[2] != "...." {
+		t.Errorf("pointer changed other lines: %q", got)
+	}
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/museslabs/kyma/internal/record"
+	"github.com/museslabs/kyma/internal/tui/transitions"
+)
+
+// Record runs through the deck starting at root without a terminal, at width
+// by height cells, and returns the screens it shows: every slide for hold,
+// and the transitions and entrance animations frame by frame at the frame
+// rate they are played at, with annotations drawn over the slides.
+func Record(root *Slide, deck Deck, annotations Annotations, width, height int, hold time.Duration) []record.Frame {
+	var m tea.Model = newModel(root, deck, "", annotations)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+
+	var frames []record.Frame
+	capture := func(duration time.Duration) {
+		screen := m.View()
+		if n := len(frames); n > 0 && frames[n-1].Screen == screen {
+			frames[n-1].Duration += duration
+			return
+		}
+		frames = append(frames, record.Frame{Screen: screen, Duration: duration})
+	}
+	animating := func() bool {
+		slide := m.(model).slide
+		return slide.ActiveTransition != nil && slide.ActiveTransition.Animating() ||
+			slide.entrance.Animating()
+	}
+	// frameRate returns the frame rate of the transition playing, or of the
+	// entrance animations once it is over
+	frameRate := func() int {
+		if t := m.(model).slide.ActiveTransition; t != nil && t.Animating() {
+			return transitions.FrameRate(t)
+		}
+		return transitions.Fps
+	}
+	play := func() {
+		for animating() {
+			capture(time.Second / time.Duration(frameRate()))
+			m, _ = m.Update(transitions.FrameMsg(time.Now()))
+		}
+	}
+
+	play()
+	for {
+		capture(hold)
+		if m.(model).slide.NextVisible() == nil {
+			return frames
+		}
+
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+		play()
+	}
+}
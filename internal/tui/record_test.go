@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/museslabs/kyma/internal/config"
+	"github.com/museslabs/kyma/internal/tui/transitions"
+)
+
+func TestRecord(t *testing.T) {
+	swipe, err := transitions.Get("swipeLeft", transitions.Params{Fps: 30})
+	if err != nil {
+		t.Fatalf("transitions.Get() error = %v", err)
+	}
+
+	var root, prev *Slide
+	for _, transition := range []transitions.Transition{transitions.None(), transitions.None(), swipe} {
+		slide, err := NewSlide("# Slide", "", config.Properties{Transition: transition})
+		if err != nil {
+			t.Fatalf("NewSlide() error = %v", err)
+		}
+		if prev == nil {
+			root = slide
+		} else {
+			prev.Next, slide.Prev = slide, prev
+		}
+		prev = slide
+	}
+
+	frames := Record(root, Deck{}, NewAnnotations(), 40, 10, time.Second)
+
+	var total time.Duration
+	swiped := false
+	for _, frame := range frames {
+		total += frame.Duration
+		swiped = swiped || frame.Duration == time.Second/30
+	}
+	// Moving to the last slide plays a swipe, frame by frame
+	if len(frames) <= 3 {
+		t.Fatalf("got %d frames, want a frame per slide and the swipe", len(frames))
+	}
+	if total < 3*time.Second {
+		t.Errorf("recording lasts %v, want every slide held for a second", total)
+	}
+	if !swiped {
+		t.Error("no frame of the swipe lasts 1/30s, want it recorded at its own frame rate")
+	}
+}
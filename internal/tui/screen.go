@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"image/color"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	charmansi "github.com/charmbracelet/x/ansi"
+
+	"github.com/museslabs/kyma/internal/config"
+)
+
+// Screen is what the audience is shown.
+type Screen string
+
+const (
+	// ScreenLive shows the slide the presenter is on.
+	ScreenLive Screen = "live"
+	// ScreenFrozen keeps showing the slide the presenter was on when the
+	// screen was frozen, while they move on.
+	ScreenFrozen Screen = "frozen"
+	// ScreenBlack blanks the screen to black.
+	ScreenBlack Screen = "black"
+	// ScreenWhite blanks the screen to the background of the theme.
+	ScreenWhite Screen = "white"
+)
+
+// whiteoutColor is used to blank the screen with themes that leave the
+// background to the terminal.
+const whiteoutColor = "#FFFFFF"
+
+// blankView fills the terminal with the color of a blanked screen.
+func blankView(screen Screen, width, height int, theme config.GlamourTheme) string {
+	color := "#000000"
+	if screen == ScreenWhite {
+		color = whiteoutColor
+		if bg := theme.Style.Document.BackgroundColor; bg != nil && *bg != "" {
+			color = *bg
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Width(width).
+		Height(height).
+		Background(lipgloss.Color(color)).
+		Render("")
+}
+
+// themeBackground returns the background color of theme, or nil if it leaves
+// the background to the terminal.
+func themeBackground(theme config.GlamourTheme) color.Color {
+	bg := theme.Style.Document.BackgroundColor
+	if bg == nil || *bg == "" {
+		return nil
+	}
+	if n, err := strconv.Atoi(*bg); err == nil && n >= 0 && n < 256 {
+		return charmansi.ExtendedColor(n)
+	}
+	return charmansi.XParseColor(*bg)
+}
@@ -0,0 +1,187 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/museslabs/kyma/internal/config"
+	"github.com/museslabs/kyma/internal/tui/transitions"
+)
+
+func screenModel() (model, []*Slide) {
+	slides := linkSlides("", "", "")
+	for _, slide := range slides {
+		slide.Properties.Transition = transitions.None()
+	}
+	return model{
+		slide:       slides[0],
+		rootSlide:   slides[0],
+		keys:        keys,
+		annotations: NewAnnotations(),
+	}, slides
+}
+
+func press(m model, key string) model {
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	return updated.(model)
+}
+
+func TestModel_Freeze(t *testing.T) {
+	m, slides := screenModel()
+
+	m = press(m, "F")
+	if m.screen() != ScreenFrozen {
+		t.Fatalf("screen() = %q, want %q", m.screen(), ScreenFrozen)
+	}
+
+	m = press(m, "l")
+	if m.slide != slides[1] {
+		t.Error("presenter did not move on while frozen")
+	}
+	if m.displayed() != slides[0] {
+		t.Error("displayed slide changed while frozen")
+	}
+
+	m = press(m, "F")
+	if m.screen() != ScreenLive || m.displayed() != slides[1] {
+		t.Error("unfreezing did not show the presenter's slide")
+	}
+}
+
+func TestModel_Blank(t *testing.T) {
+	m, _ := screenModel()
+
+	m = press(m, "b")
+	if m.screen() != ScreenBlack {
+		t.Fatalf("screen() = %q, want %q", m.screen(), ScreenBlack)
+	}
+
+	m = press(m, "w")
+	if m.screen() != ScreenWhite {
+		t.Fatalf("screen() = %q, want %q", m.screen(), ScreenWhite)
+	}
+
+	m = press(m, "w")
+	if m.screen() != ScreenLive {
+		t.Errorf("screen() = %q, want %q", m.screen(), ScreenLive)
+	}
+}
+
+func TestModel_RemoteGoTo(t *testing.T) {
+	m, slides := screenModel()
+	m = press(m, "F")
+
+	updated, _ := m.Update(RemoteGoToMsg{SlideNumber: 2})
+	m = updated.(model)
+
+	if m.slide != slides[2] {
+		t.Error("remote go to did not move the presenter")
+	}
+	if m.displayed() != slides[0] {
+		t.Error("remote go to changed the frozen slide")
+	}
+}
+
+func TestRenderMeter(t *testing.T) {
+	t.Cleanup(func() { transitions.SetReducedMotion(transitions.ReducedMotionOff) })
+
+	swipe, err := transitions.Get("swipeLeft", transitions.Params{})
+	if err != nil {
+		t.Fatalf("transitions.Get() error = %v", err)
+	}
+
+	r := &renderMeter{}
+	r.record(2 * slowRender)
+	if r.slow {
+		t.Fatal("a single slow view made rendering slow")
+	}
+	for range renderSmoothing {
+		r.record(2 * slowRender)
+	}
+	if !r.slow {
+		t.Fatal("rendering is not slow after slow views")
+	}
+
+	transitions.SetReducedMotion(transitions.ReducedMotionOff)
+	if got := r.reduce(swipe).Name(); got != "swipeLeft" {
+		t.Errorf("reduce() with off = %q, want swipeLeft", got)
+	}
+	transitions.SetReducedMotion(transitions.ReducedMotionAuto)
+	if got := r.reduce(swipe).Name(); got != "none" {
+		t.Errorf("reduce() with auto while slow = %q, want none", got)
+	}
+
+	for range 2 * renderSmoothing {
+		r.record(0)
+	}
+	if r.slow {
+		t.Fatal("rendering is still slow after fast views")
+	}
+	if got := r.reduce(swipe).Name(); got != "swipeLeft" {
+		t.Errorf("reduce() with auto once fast = %q, want swipeLeft", got)
+	}
+}
+
+func TestModel_UpdateSlidesSkipped(t *testing.T) {
+	m, slides := screenModel()
+	m.slide = slides[1]
+
+	reload := func(skipped ...bool) []*Slide {
+		t.Helper()
+		reloaded := linkSlides(make([]string, len(skipped))...)
+		for i, slide := range reloaded {
+			slide.Properties.Transition = transitions.None()
+			slide.Skipped = skipped[i]
+		}
+		updated, _ := m.Update(UpdateSlidesMsg{NewRoot: reloaded[0]})
+		m = updated.(model)
+		return reloaded
+	}
+
+	reloaded := reload(false, true, false)
+	if m.slide != reloaded[2] {
+		t.Errorf("slide = %d after the current slide was hidden, want the next one", m.slide.position())
+	}
+
+	reloaded = reload(false, false, true)
+	if m.slide != reloaded[1] {
+		t.Errorf("slide = %d after the last slides were hidden, want the previous one", m.slide.position())
+	}
+
+	reloaded = reload(false)
+	if m.slide != reloaded[0] {
+		t.Errorf("slide = %d after the deck shrank, want the last one", m.slide.position())
+	}
+}
+
+func TestModel_UpdateSlidesDeck(t *testing.T) {
+	m, _ := screenModel()
+
+	slides := linkSlides("", "", "")
+	for _, slide := range slides {
+		slide.Properties.Transition = transitions.None()
+	}
+	fade, err := transitions.Get("fade", transitions.Params{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deck := Deck{Config: config.DeckConfig{Title: "Reloaded"}, JumpTransition: fade}
+
+	updated, _ := m.Update(UpdateSlidesMsg{NewRoot: slides[0], Deck: deck})
+	m = updated.(model)
+	if m.deck.Config.Title != "Reloaded" {
+		t.Errorf("deck title = %q, want the reloaded one", m.deck.Config.Title)
+	}
+	for i, slide := range slides {
+		if slide.deck == nil || slide.deck.Config.Title != "Reloaded" {
+			t.Errorf("slide %d does not have the reloaded deck", i)
+		}
+	}
+
+	// Jumping over a slide plays the jump transition of the reloaded deck
+	m.moveTo(slides[2])
+	if got := m.slide.ActiveTransition.Name(); got != "fade" {
+		t.Errorf("jump transition = %q, want fade", got)
+	}
+}
@@ -1,7 +1,11 @@
 package tui
 
 import (
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -18,36 +22,143 @@ type Slide struct {
 	ActiveTransition transitions.Transition
 	Properties       config.Properties
 	Timer            Timer
+	// Skipped slides are hidden or meant for another audience. Navigation and
+	// slide counts leave them out, but they can still be opened from the
+	// command palette.
+	Skipped bool
 
-	renderer *markdown.Renderer
+	deck       *Deck
+	renderer   *markdown.Renderer
+	images     []string
+	links      []markdown.Link
+	animations []markdown.Animation
+	entrance   entrance
+	// from is the slide the active transition starts from. Without it, the
+	// transition starts from the closest slide in its direction.
+	from *Slide
+}
+
+// Deck holds the settings of the presentation read while it is shown. They
+// are parsed along with its slides and handed to the model with them, since
+// reloading the presentation writes the config package from another
+// goroutine.
+type Deck struct {
+	Config config.DeckConfig
+	// JumpTransition is played when moving between slides that are not
+	// next to each other.
+	JumpTransition transitions.Transition
 }
 
 type UpdateSlidesMsg struct {
 	NewRoot *Slide
+	Deck    Deck
+}
+
+// attachDeck makes deck the settings of root and the slides after it.
+func attachDeck(root *Slide, deck *Deck) {
+	for s := root; s != nil; s = s.Next {
+		s.deck = deck
+	}
+}
+
+// AssetsChangedMsg reports files on disk, such as images, that changed without
+// requiring the deck to be parsed again. Paths are absolute.
+type AssetsChangedMsg struct {
+	Paths []string
 }
 
-func NewSlide(data string, props config.Properties) (*Slide, error) {
+// NewSlide returns the slide showing data, the markdown read from a file in
+// dir, which the relative paths of its images are relative to. An empty dir
+// is the working directory.
+func NewSlide(data, dir string, props config.Properties) (*Slide, error) {
 	themeName := "dark"
 	if props.Style.Theme != nil && props.Style.Theme.Name != "" {
 		themeName = props.Style.Theme.Name
 	}
 
-	r, err := markdown.NewRenderer(themeName, markdown.WithImageBackend(props.ImageBackend))
+	r, err := markdown.NewRenderer(
+		themeName,
+		markdown.WithImageBackend(props.ImageBackend),
+		markdown.WithDir(dir),
+	)
 	if err != nil {
 		return nil, err
 
 	}
 
+	animations := markdown.Animations(data)
+	if err := checkAnimations(animations); err != nil {
+		return nil, err
+	}
+
 	return &Slide{
 		Data:       data,
 		Properties: props,
 		renderer:   r,
+		images:     r.Images(data),
+		links:      markdown.Links(data),
+		animations: animations,
 	}, nil
 }
 
+// enter starts the entrance animations of the slide, or skips them when the
+// slide is reached backwards.
+func (s *Slide) enter(backwards bool) {
+	s.entrance = newEntrance(s.animations)
+	if backwards {
+		s.entrance = s.entrance.finish()
+	}
+}
+
+// prefetch starts drawing the images of the slides around s, for moving to
+// them not to wait on their images.
+func (s *Slide) prefetch() {
+	for _, neighbor := range []*Slide{s.PrevVisible(), s.NextVisible()} {
+		if neighbor != nil && neighbor.renderer != nil {
+			neighbor.renderer.Prefetch(neighbor.Data)
+		}
+	}
+}
+
+// Images returns the paths of the images referenced by the slide, relative to
+// the working directory.
+func (s *Slide) Images() []string {
+	return s.images
+}
+
+// Evict drops the cached renderings of any image of the slide that is one of
+// the given absolute paths, and reports whether the slide was affected.
+func (s *Slide) Evict(paths []string) bool {
+	if s.renderer == nil {
+		return false
+	}
+
+	affected := false
+	for _, img := range s.images {
+		abs, err := filepath.Abs(img)
+		if err != nil {
+			continue
+		}
+		for _, p := range paths {
+			if abs == p {
+				s.renderer.Evict(img)
+				affected = true
+			}
+		}
+	}
+	return affected
+}
+
 func (s *Slide) Update() (*Slide, tea.Cmd) {
-	transition, cmd := s.ActiveTransition.Update()
-	s.ActiveTransition = transition
+	var cmd tea.Cmd
+	if s.ActiveTransition != nil && s.ActiveTransition.Animating() {
+		s.ActiveTransition, cmd = s.ActiveTransition.Update()
+	}
+
+	// Blocks animate in once the slide is in place
+	if cmd == nil && s.entrance.Animating() {
+		s.entrance, cmd = s.entrance.Update()
+	}
 
 	// Update timer
 	// var timerCmd tea.Cmd
@@ -56,33 +167,192 @@ func (s *Slide) Update() (*Slide, tea.Cmd) {
 	return s, tea.Batch(cmd)
 }
 
-func (s *Slide) View(animating bool) string {
+func (s *Slide) View(animating bool, elapsed time.Duration) string {
 	var b strings.Builder
 
-	out, _ := s.renderer.Render(
-		s.Data,
-		(s.ActiveTransition != nil && s.ActiveTransition.Animating()) || animating,
-	)
+	var out string
+	if s.entrance.Animating() {
+		out, _ = s.renderer.RenderAnimated(s.Data)
+		out = s.entrance.View(out)
+	} else {
+		out, _ = s.renderer.Render(
+			s.Data,
+			(s.ActiveTransition != nil && s.ActiveTransition.Animating()) || animating,
+		)
+	}
+	out = s.frame(out, elapsed)
 
 	if s.ActiveTransition != nil && s.ActiveTransition.Animating() {
 		direction := s.ActiveTransition.Direction()
+		from := s.from
+		if from == nil {
+			from = s.neighbor(direction == transitions.Backwards)
+		}
+
 		if direction == transitions.Backwards {
-			if s.Next == nil {
+			if from == nil {
 				panic("backwards transition at the last slide")
 			} else {
-				b.WriteString(s.ActiveTransition.View(s.Next.View(true), s.Style.LipGlossStyle.Render(out)))
+				b.WriteString(s.ActiveTransition.View(from.View(true, elapsed), out))
 			}
 		} else {
-			if s.Prev != nil {
-				b.WriteString(s.ActiveTransition.View(s.Prev.View(true), s.Style.LipGlossStyle.Render(out)))
+			if from != nil {
+				b.WriteString(s.ActiveTransition.View(from.View(true, elapsed), out))
 			} else {
-				b.WriteString(s.Style.LipGlossStyle.Render(out))
+				b.WriteString(out)
 			}
 		}
 	} else {
-		b.WriteString(s.Style.LipGlossStyle.Render(out))
+		b.WriteString(out)
+	}
+
+	return b.String()
+}
+
+// neighbor returns the slide a transition starts from: the closest slide that
+// is not skipped, or the adjacent one if there is none.
+func (s *Slide) neighbor(backwards bool) *Slide {
+	if backwards {
+		if next := s.NextVisible(); next != nil {
+			return next
+		}
+		return s.Next
+	}
+
+	if prev := s.PrevVisible(); prev != nil {
+		return prev
+	}
+	return s.Prev
+}
+
+// frame renders the slide's content inside its border, along with the header
+// and footer bars.
+func (s *Slide) frame(content string, elapsed time.Duration) string {
+	if s.Properties.Header == nil && s.Properties.Footer == nil {
+		return s.Style.Render(content, "", "")
+	}
+
+	data := config.BarData{
+		SlideNumber: s.Number(),
+		Total:       s.Total(),
+		Title:       s.Properties.Title,
+		Elapsed:     formatDuration(elapsed),
+	}
+	if s.deck != nil {
+		data.DeckTitle = s.deck.Config.Title
+		data.Author = s.deck.Config.Author
+	}
+
+	width := s.Style.ContentWidth()
+	return s.Style.Render(
+		content,
+		s.Properties.Header.Render(width, data),
+		s.Properties.Footer.Render(width, data),
+	)
+}
+
+// Number returns the position of the slide among the slides not skipped,
+// starting at 1.
+func (s *Slide) Number() int {
+	n := 1
+	for current := s.PrevVisible(); current != nil; current = current.PrevVisible() {
+		n++
+	}
+	return n
+}
+
+// position returns the index of the slide in the deck, starting at 0,
+// skipped slides included.
+func (s *Slide) position() int {
+	n := 0
+	for current := s.Prev; current != nil; current = current.Prev {
+		n++
+	}
+	return n
+}
+
+// remaining returns the number of slides not skipped after this one.
+func (s *Slide) remaining() int {
+	n := 0
+	for current := s.NextVisible(); current != nil; current = current.NextVisible() {
+		n++
+	}
+	return n
+}
+
+// Total returns the number of slides not skipped in the deck.
+func (s *Slide) Total() int {
+	total := s.Number() + s.remaining()
+	if s.Skipped {
+		total--
+	}
+	return total
+}
+
+// Resolve returns the slide an internal link points to: slide:N is the Nth
+// slide not skipped, and #id the slide with that id, or whose title or first
+// heading has id as slug. It returns nil if there is no such slide.
+func (s *Slide) Resolve(target string) *Slide {
+	if n, ok := strings.CutPrefix(target, "slide:"); ok {
+		number, err := strconv.Atoi(n)
+		if err != nil || number < 1 {
+			return nil
+		}
+
+		slide := s.FirstVisible()
+		for i := 1; i < number && slide != nil; i++ {
+			slide = slide.NextVisible()
+		}
+		return slide
+	}
+
+	id, ok := strings.CutPrefix(target, "#")
+	if !ok || id == "" {
+		return nil
+	}
+
+	for slide := s.First(); slide != nil; slide = slide.Next {
+		if slide.Properties.ID == id {
+			return slide
+		}
+	}
+	for slide := s.First(); slide != nil; slide = slide.Next {
+		if slug(slide.Properties.Title) == id || slug(heading(slide.Data)) == id {
+			return slide
+		}
 	}
 
+	return nil
+}
+
+// heading returns the text of the first ATX heading of the markdown in data.
+func heading(data string) string {
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if text, ok := strings.CutPrefix(strings.TrimLeft(line, "#"), " "); ok && strings.HasPrefix(line, "#") {
+			return strings.TrimSpace(text)
+		}
+	}
+	return ""
+}
+
+// slug turns a title into the form used by anchors: lower case words joined
+// with dashes, punctuation removed.
+func slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(title)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			dash = true
+		}
+	}
 	return b.String()
 }
 
@@ -101,3 +371,101 @@ func (s *Slide) Last() *Slide {
 	}
 	return current
 }
+
+// NextVisible returns the closest slide after this one that is not skipped,
+// or nil if there is none.
+func (s *Slide) NextVisible() *Slide {
+	current := s.Next
+	for current != nil && current.Skipped {
+		current = current.Next
+	}
+	return current
+}
+
+// PrevVisible returns the closest slide before this one that is not skipped,
+// or nil if there is none.
+func (s *Slide) PrevVisible() *Slide {
+	current := s.Prev
+	for current != nil && current.Skipped {
+		current = current.Prev
+	}
+	return current
+}
+
+// ClosestVisible returns the slide when it is not skipped, or else the closest
+// slide after it that is not, or before it. It returns the slide itself if
+// every slide is skipped.
+func (s *Slide) ClosestVisible() *Slide {
+	if !s.Skipped {
+		return s
+	}
+	if next := s.NextVisible(); next != nil {
+		return next
+	}
+	if prev := s.PrevVisible(); prev != nil {
+		return prev
+	}
+	return s
+}
+
+// FirstVisible returns the first slide of the deck that is not skipped, or
+// the first slide if they all are.
+func (s *Slide) FirstVisible() *Slide {
+	first := s.First()
+	if first.Skipped {
+		if next := first.NextVisible(); next != nil {
+			return next
+		}
+	}
+	return first
+}
+
+// LastVisible returns the last slide of the deck that is not skipped, or the
+// last slide if they all are.
+func (s *Slide) LastVisible() *Slide {
+	last := s.Last()
+	if last.Skipped {
+		if prev := last.PrevVisible(); prev != nil {
+			return prev
+		}
+	}
+	return last
+}
+
+// NextSection returns the first slide of the section after the slide's, or
+// nil if it is in the last section.
+func (s *Slide) NextSection() *Slide {
+	for current := s.NextVisible(); current != nil; current = current.NextVisible() {
+		if current.Properties.Section != s.Properties.Section {
+			return current
+		}
+	}
+	return nil
+}
+
+// PrevSection returns the first slide of the slide's section, or of the
+// previous section if the slide already starts its own.
+func (s *Slide) PrevSection() *Slide {
+	start := s.sectionStart()
+	if start != s {
+		return start
+	}
+
+	prev := start.PrevVisible()
+	if prev == nil {
+		return nil
+	}
+	return prev.sectionStart()
+}
+
+// sectionStart returns the first slide not skipped of the run of slides in
+// the same section as s, or s itself.
+func (s *Slide) sectionStart() *Slide {
+	start := s
+	for current := s.Prev; current != nil && current.Properties.Section == s.Properties.Section; current = current.Prev {
+		if !current.Skipped {
+			start = current
+		}
+	}
+	return start
+}
@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/museslabs/kyma/internal/config"
+	"github.com/museslabs/kyma/internal/img"
+)
+
+func linkSlides(sections ...string) []*Slide {
+	slides := make([]*Slide, len(sections))
+	for i, section := range sections {
+		slides[i] = &Slide{Properties: config.Properties{Section: section}}
+		if i > 0 {
+			slides[i-1].Next = slides[i]
+			slides[i].Prev = slides[i-1]
+		}
+	}
+	return slides
+}
+
+func TestSlide_SectionNavigation(t *testing.T) {
+	slides := linkSlides("", "Intro", "Intro", "Internals", "Internals", "Outro")
+
+	tests := []struct {
+		name string
+		from int
+		next int
+		prev int
+	}{
+		{name: "before any section", from: 0, next: 1, prev: -1},
+		{name: "start of a section", from: 1, next: 3, prev: 0},
+		{name: "middle of a section", from: 4, next: 5, prev: 3},
+		{name: "last section", from: 5, next: -1, prev: 3},
+	}
+
+	index := func(s *Slide) int {
+		for i, slide := range slides {
+			if slide == s {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := index(slides[tt.from].NextSection()); got != tt.next {
+				t.Errorf("NextSection() = slide %d, want %d", got, tt.next)
+			}
+			if got := index(slides[tt.from].PrevSection()); got != tt.prev {
+				t.Errorf("PrevSection() = slide %d, want %d", got, tt.prev)
+			}
+		})
+	}
+}
+
+func TestSlide_Number(t *testing.T) {
+	slides := linkSlides("", "", "")
+	for i, s := range slides {
+		if got := s.Number(); got != i+1 {
+			t.Errorf("Number() = %d, want %d", got, i+1)
+		}
+	}
+}
+
+func TestSlide_SkippedNavigation(t *testing.T) {
+	slides := linkSlides("", "", "", "", "")
+	slides[0].Skipped = true
+	slides[2].Skipped = true
+	slides[4].Skipped = true
+
+	if got := slides[0].FirstVisible(); got != slides[1] {
+		t.Errorf("FirstVisible() should skip the hidden first slide")
+	}
+	if got := slides[0].LastVisible(); got != slides[3] {
+		t.Errorf("LastVisible() should skip the hidden last slide")
+	}
+	if got := slides[1].NextVisible(); got != slides[3] {
+		t.Errorf("NextVisible() should skip hidden slides")
+	}
+	if got := slides[3].PrevVisible(); got != slides[1] {
+		t.Errorf("PrevVisible() should skip hidden slides")
+	}
+	if got := slides[3].NextVisible(); got != nil {
+		t.Errorf("NextVisible() at the last visible slide should be nil")
+	}
+
+	for i, want := range []int{1, 1, 2, 2, 3} {
+		if got := slides[i].Number(); got != want {
+			t.Errorf("slide %d Number() = %d, want %d", i, got, want)
+		}
+		if got := slides[i].Total(); got != 2 {
+			t.Errorf("slide %d Total() = %d, want 2", i, got)
+		}
+	}
+}
+
+func TestSlide_Prefetch(t *testing.T) {
+	img.SetAsync(true)
+	t.Cleanup(func() { img.SetAsync(false) })
+
+	dir := t.TempDir()
+	paths := make([]string, 3)
+	slides := make([]*Slide, 3)
+	for i := range slides {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("%d.png", i))
+		f, err := os.Create(paths[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := png.Encode(f, image.NewNRGBA(image.Rect(0, 0, 4, 2))); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+
+		data := fmt.Sprintf("![image %d|4x2](%s)", i, paths[i])
+		slides[i], err = NewSlide(data, "", config.Properties{ImageBackend: "native"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i > 0 {
+			slides[i-1].Next = slides[i]
+			slides[i].Prev = slides[i-1]
+		}
+	}
+
+	// Only the images of the slides around the current one are drawn
+	slides[1].prefetch()
+	want := map[string]bool{paths[0]: true, paths[2]: true}
+	timeout := time.After(5 * time.Second)
+	for len(want) > 0 {
+		select {
+		case p := <-img.Loaded():
+			if p == paths[1] {
+				t.Fatalf("prefetch() drew the image of the current slide")
+			}
+			delete(want, p)
+		case <-timeout:
+			t.Fatalf("prefetch() did not draw %v", want)
+		}
+	}
+}
+
+func TestSlide_ImagesRelativeToDir(t *testing.T) {
+	abs := filepath.Join(t.TempDir(), "b.png")
+	slide, err := NewSlide(
+		fmt.Sprintf("![a](a.png)\n\n![b](%s)\n", abs),
+		filepath.Join("talk", "parts"),
+		config.Properties{ImageBackend: "native"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{filepath.Join("talk", "parts", "a.png"), abs}
+	if got := slide.Images(); !slices.Equal(got, want) {
+		t.Errorf("Images() = %v, want %v", got, want)
+	}
+
+	a, err := filepath.Abs(want[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slide.Evict([]string{a}) {
+		t.Error("Evict() did not find the image relative to the slide")
+	}
+}
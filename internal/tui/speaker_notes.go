@@ -40,8 +40,11 @@ type SpeakerNotesModel struct {
 	height           int
 	currentSlide     int
 	slides           []*Slide
+	deck             Deck
 	syncClient       *SyncClient
 	slideChangeChan  chan int
+	screenChangeChan chan Screen
+	screen           Screen
 	connectionStatus ConnectionStatus
 }
 
@@ -49,6 +52,12 @@ type SlideChangeMsg struct {
 	SlideNumber int
 }
 
+// ScreenChangeMsg is sent when the presentation changes what the audience is
+// shown.
+type ScreenChangeMsg struct {
+	Screen Screen
+}
+
 type ConnectionLostMsg struct{}
 
 type ReconnectAttemptMsg struct{}
@@ -57,7 +66,7 @@ type ReconnectedMsg struct {
 	Client *SyncClient
 }
 
-func NewSpeakerNotes(rootSlide *Slide) SpeakerNotesModel {
+func NewSpeakerNotes(rootSlide *Slide, deck Deck) SpeakerNotesModel {
 	// Create slides array for easier indexing
 	var slides []*Slide
 	slide := rootSlide
@@ -79,12 +88,16 @@ func NewSpeakerNotes(rootSlide *Slide) SpeakerNotesModel {
 
 	// Create buffered channel for slide changes
 	slideChangeChan := make(chan int)
+	screenChangeChan := make(chan Screen)
 
 	return SpeakerNotesModel{
 		currentSlide:     0,
 		slides:           slides,
+		deck:             deck,
 		syncClient:       syncClient,
 		slideChangeChan:  slideChangeChan,
+		screenChangeChan: screenChangeChan,
+		screen:           ScreenLive,
 		connectionStatus: status,
 	}
 }
@@ -109,8 +122,12 @@ func (m SpeakerNotesModel) Init() tea.Cmd {
 
 func (m SpeakerNotesModel) waitForSlideChange() tea.Cmd {
 	return func() tea.Msg {
-		slideNum := <-m.slideChangeChan
-		return SlideChangeMsg{SlideNumber: slideNum}
+		select {
+		case slideNum := <-m.slideChangeChan:
+			return SlideChangeMsg{SlideNumber: slideNum}
+		case screen := <-m.screenChangeChan:
+			return ScreenChangeMsg{Screen: screen}
+		}
 	}
 }
 
@@ -120,7 +137,7 @@ func (m SpeakerNotesModel) listenForSlideChangesWithReconnect() {
 	}
 
 	// Listen for slide changes and detect disconnection
-	m.syncClient.ListenForSlideChanges(m.slideChangeChan)
+	m.syncClient.ListenForSlideChanges(m.slideChangeChan, m.screenChangeChan)
 
 	// If we reach here, the connection was lost
 	m.slideChangeChan <- -1
@@ -159,6 +176,9 @@ func (m SpeakerNotesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Continue waiting for more slide changes
 		return m, m.waitForSlideChange()
+	case ScreenChangeMsg:
+		m.screen = msg.Screen
+		return m, m.waitForSlideChange()
 	case ReconnectAttemptMsg:
 		m.connectionStatus = StatusReconnecting
 		// Wait a bit before trying again
@@ -178,12 +198,27 @@ func (m SpeakerNotesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.syncClient.Close()
 			}
 			return m, tea.Quit
+		case "right", "l", " ":
+			m.goTo(m.slides[m.currentSlide].NextVisible())
+		case "left", "h":
+			m.goTo(m.slides[m.currentSlide].PrevVisible())
 		}
 	}
 
 	return m, nil
 }
 
+// goTo asks the presentation to move to slide, which shows it to the audience
+// unless the screen is frozen.
+func (m SpeakerNotesModel) goTo(slide *Slide) {
+	if m.syncClient == nil || slide == nil {
+		return
+	}
+	if err := m.syncClient.GoTo(slide.position()); err != nil {
+		slog.Error("Failed to send slide change", "error", err)
+	}
+}
+
 func (m SpeakerNotesModel) View() string {
 	if len(m.slides) == 0 {
 		return "No slides available"
@@ -200,7 +235,19 @@ func (m SpeakerNotesModel) View() string {
 		notes = "No speaker notes for this slide."
 	}
 
-	headerText := fmt.Sprintf("Speaker Notes - Slide %d/%d (%s)", m.currentSlide+1, len(m.slides), m.connectionStatus)
+	// Number slides like the presentation does, leaving skipped ones out
+	slideText := fmt.Sprintf("Slide %d/%d", slide.Number(), slide.Total())
+	if slide.Skipped {
+		slideText = "Hidden slide"
+	}
+
+	headerText := fmt.Sprintf("Speaker Notes - %s (%s)", slideText, m.connectionStatus)
+	if m.screen != ScreenLive {
+		headerText = fmt.Sprintf("%s [screen %s]", headerText, m.screen)
+	}
+	if title := m.deck.Config.Title; title != "" {
+		headerText = fmt.Sprintf("%s - %s", title, headerText)
+	}
 
 	header := lipgloss.NewStyle().
 		Bold(true).
@@ -10,6 +10,9 @@ import (
 	"sync"
 )
 
+// SyncServer keeps speaker notes in sync with the presentation. Its clients,
+// and the slide and screen they are greeted with as they connect, are
+// guarded by clientsMu.
 type SyncServer struct {
 	listener     net.Listener
 	port         int
@@ -17,6 +20,8 @@ type SyncServer struct {
 	clientsMu    sync.Mutex
 	running      bool
 	currentSlide int
+	screen       Screen
+	goTo         chan int
 }
 
 const port = 34622
@@ -32,6 +37,8 @@ func NewSyncServer() (*SyncServer, error) {
 		port:         port,
 		clients:      make(map[net.Conn]struct{}),
 		currentSlide: 0,
+		screen:       ScreenLive,
+		goTo:         make(chan int),
 	}
 
 	return server, nil
@@ -65,10 +72,26 @@ func (s *SyncServer) Stop() {
 }
 
 func (s *SyncServer) BroadcastSlideChange(slideNumber int) {
+	s.clientsMu.Lock()
 	s.currentSlide = slideNumber
+	s.clientsMu.Unlock()
+	s.broadcast(fmt.Sprintf("SLIDE:%d\n", slideNumber))
+}
+
+// BroadcastScreenChange tells clients what the audience is shown.
+func (s *SyncServer) BroadcastScreenChange(screen Screen) {
+	s.clientsMu.Lock()
+	s.screen = screen
+	s.clientsMu.Unlock()
+	s.broadcast(fmt.Sprintf("SCREEN:%s\n", screen))
+}
 
-	message := fmt.Sprintf("SLIDE:%d\n", slideNumber)
+// GoTo receives the slide positions clients ask the presentation to move to.
+func (s *SyncServer) GoTo() <-chan int {
+	return s.goTo
+}
 
+func (s *SyncServer) broadcast(message string) {
 	s.clientsMu.Lock()
 	for client := range s.clients {
 		_, err := client.Write([]byte(message))
@@ -93,22 +116,41 @@ func (s *SyncServer) acceptConnections() error {
 			return fmt.Errorf("failed to accept connection: %w", err)
 		}
 
+		// The client is greeted under the lock, for no change broadcast
+		// meanwhile to reach it before the state it changes
 		s.clientsMu.Lock()
-		s.clients[conn] = struct{}{}
-		s.clientsMu.Unlock()
-
-		currentSlide := s.currentSlide
-
-		message := fmt.Sprintf("SLIDE:%d\n", currentSlide)
+		message := fmt.Sprintf("SLIDE:%d\nSCREEN:%s\n", s.currentSlide, s.screen)
 		_, err = conn.Write([]byte(message))
+		if err == nil {
+			s.clients[conn] = struct{}{}
+		}
+		s.clientsMu.Unlock()
 		if err != nil {
+			conn.Close()
 			return fmt.Errorf("failed to send current slide to new client: %w", err)
 		}
 
+		go s.handleClient(conn)
 	}
 	return nil
 }
 
+// handleClient forwards the slides a client asks to go to until it
+// disconnects.
+func (s *SyncServer) handleClient(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "GOTO:") {
+			if slideNum, err := strconv.Atoi(strings.TrimPrefix(line, "GOTO:")); err == nil {
+				s.goTo <- slideNum
+			}
+		}
+	}
+}
+
 type SyncClient struct {
 	conn net.Conn
 	port int
@@ -128,7 +170,7 @@ func NewSyncClient() (*SyncClient, error) {
 	return client, nil
 }
 
-func (c *SyncClient) ListenForSlideChanges(slideChangeChan chan<- int) {
+func (c *SyncClient) ListenForSlideChanges(slideChangeChan chan<- int, screenChangeChan chan<- Screen) {
 	scanner := bufio.NewScanner(c.conn)
 
 	for scanner.Scan() {
@@ -139,10 +181,18 @@ func (c *SyncClient) ListenForSlideChanges(slideChangeChan chan<- int) {
 			if slideNum, err := strconv.Atoi(slideNumStr); err == nil {
 				slideChangeChan <- slideNum
 			}
+		} else if strings.HasPrefix(line, "SCREEN:") {
+			screenChangeChan <- Screen(strings.TrimPrefix(line, "SCREEN:"))
 		}
 	}
 }
 
+// GoTo asks the presentation to move to the slide at slideNumber.
+func (c *SyncClient) GoTo(slideNumber int) error {
+	_, err := fmt.Fprintf(c.conn, "GOTO:%d\n", slideNumber)
+	return err
+}
+
 func (c *SyncClient) Close() {
 	if c.conn != nil {
 		c.conn.Close()
@@ -77,7 +77,10 @@ func (t Timer) Duration() time.Duration {
 }
 
 func (t Timer) FormatDuration() string {
-	d := t.Duration()
+	return formatDuration(t.Duration())
+}
+
+func formatDuration(d time.Duration) string {
 	totalSeconds := int(d.Seconds())
 	minutes := totalSeconds / 60
 	seconds := totalSeconds % 60
@@ -103,7 +106,7 @@ func (td TimerDisplay) Update(msg tea.Msg) (TimerDisplay, tea.Cmd) {
 	return td, nil
 }
 
-func (td TimerDisplay) Show(slideView string, width, height int, globalTimer Timer, slideTimer Timer) string {
+func (td TimerDisplay) Show(slideView string, width, height int, globalTimer Timer, slideTimer Timer, target time.Duration) string {
 	if !td.visible {
 		return slideView
 	}
@@ -111,9 +114,19 @@ func (td TimerDisplay) Show(slideView string, width, height int, globalTimer Tim
 	globalTimeStr := globalTimer.FormatDuration()
 	slideTimeStr := slideTimer.FormatDuration()
 
+	foreground := lipgloss.Color("#DDDDDD")
+
+	// Show the time left against the deck's target duration, if any
+	if target > 0 {
+		globalTimeStr += " / " + formatDuration(target)
+		if globalTimer.Duration() > target {
+			foreground = lipgloss.Color("#FF5555")
+		}
+	}
+
 	timerContent := lipgloss.NewStyle().
 		Background(lipgloss.Color("#2A2A2A")).
-		Foreground(lipgloss.Color("#DDDDDD")).
+		Foreground(foreground).
 		Padding(0, 1).
 		Render(fmt.Sprintf("Total:  %s\nSlide:  %s", globalTimeStr, slideTimeStr))
 
@@ -0,0 +1,194 @@
+package transitions
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/cellbuf"
+)
+
+// defaultBackground is blended through by transitions that fade slides when
+// no background was set with [WithBackground].
+var defaultBackground color.Color = ansi.TrueColor(0x000000)
+
+// Blender is implemented by transitions that blend the colors of slides
+// through the background they are displayed on.
+type Blender interface {
+	Transition
+	WithBackground(background color.Color) Transition
+}
+
+// WithBackground sets the background t blends slides through, if it does.
+func WithBackground(t Transition, background color.Color) Transition {
+	if b, ok := t.(Blender); ok && background != nil {
+		return b.WithBackground(background)
+	}
+	return t
+}
+
+// parseFrames parses two rendered slides into grids of styled cells of the
+// same size, so that transitions can blend them cell by cell rather than by
+// slicing their lines.
+func parseFrames(prev, next string) (*cellbuf.Buffer, *cellbuf.Buffer) {
+	width, height := frameSize(prev)
+	if w, h := frameSize(next); w > width || h > height {
+		width, height = max(width, w), max(height, h)
+	}
+
+	prevBuf := cellbuf.NewBuffer(width, height)
+	cellbuf.SetContent(prevBuf, prev)
+	nextBuf := cellbuf.NewBuffer(width, height)
+	cellbuf.SetContent(nextBuf, next)
+
+	return prevBuf, nextBuf
+}
+
+func frameSize(frame string) (int, int) {
+	lines := strings.Split(frame, "\n")
+	width := 0
+	for _, line := range lines {
+		width = max(width, ansi.StringWidth(line))
+	}
+	return width, len(lines)
+}
+
+// renderFrame turns a grid of cells back into lines, padded to its width.
+func renderFrame(buf *cellbuf.Buffer) string {
+	var s strings.Builder
+	for y := range buf.Height() {
+		w, line := cellbuf.RenderLine(buf, y)
+		s.WriteString(line)
+		s.WriteString(strings.Repeat(" ", max(buf.Width()-w, 0)))
+		if y < buf.Height()-1 {
+			s.WriteString("\n")
+		}
+	}
+	return s.String()
+}
+
+// mixFrames builds a grid taking each cell from prev or next as chosen by
+// fromNext. Wide characters are kept whole.
+func mixFrames(prev, next *cellbuf.Buffer, fromNext func(x, y int) bool) *cellbuf.Buffer {
+	out := cellbuf.NewBuffer(prev.Width(), prev.Height())
+	for y := range out.Height() {
+		for x := 0; x < out.Width(); x++ {
+			src := prev
+			if fromNext(x, y) {
+				src = next
+			}
+
+			c := src.Cell(x, y)
+			if c == nil || c.Width == 0 {
+				// The rest of a wide character taken from the other frame
+				blank := cellbuf.BlankCell
+				c = &blank
+			}
+
+			out.SetCell(x, y, c)
+			if c.Width > 1 {
+				x += c.Width - 1
+			}
+		}
+	}
+	return out
+}
+
+// fadeFrame returns a copy of buf with the colors of every cell moved towards
+// background by amount, from 0 for unchanged to 1 for fully faded.
+func fadeFrame(buf *cellbuf.Buffer, background color.Color, amount float64) *cellbuf.Buffer {
+	if amount <= 0 {
+		return buf
+	}
+
+	foreground := contrasting(background)
+
+	out := cellbuf.NewBuffer(buf.Width(), buf.Height())
+	for y := range buf.Height() {
+		for x := range buf.Width() {
+			c := buf.Cell(x, y)
+			if c != nil && c.Width == 0 {
+				continue
+			}
+			if c == nil {
+				blank := cellbuf.BlankCell
+				c = &blank
+			}
+
+			c = c.Clone()
+			fg, bg := c.Style.Fg, c.Style.Bg
+			if fg == nil {
+				fg = foreground
+			}
+			if bg == nil {
+				bg = background
+			}
+			c.Style.Fg = blend(fg, background, amount)
+			c.Style.Bg = blend(bg, background, amount)
+			out.SetCell(x, y, c)
+		}
+	}
+	return out
+}
+
+// blend interpolates linearly between the colors a and b, t going from 0 for
+// a to 1 for b.
+func blend(a, b color.Color, t float64) ansi.Color {
+	t = min(max(t, 0), 1)
+
+	ar, ag, ab, _ := a.RGBA()
+	br, bg, bb, _ := b.RGBA()
+
+	mix := func(x, y uint32) uint32 {
+		return uint32(float64(x>>8)+(float64(y>>8)-float64(x>>8))*t+0.5) & 0xff
+	}
+
+	return ansi.TrueColor(mix(ar, br)<<16 | mix(ag, bg)<<8 | mix(ab, bb))
+}
+
+// contrasting returns the color text is assumed to have on background when
+// it sets none: white on dark backgrounds, black on light ones.
+func contrasting(background color.Color) color.Color {
+	r, g, b, _ := background.RGBA()
+	luminance := (0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)) / 0xffff
+	if luminance > 0.5 {
+		return ansi.TrueColor(0x000000)
+	}
+	return ansi.TrueColor(0xffffff)
+}
+
+// sample returns the cell of buf at x, y as a single column wide cell, so that
+// frames can be resampled without breaking their alignment. Cells out of
+// bounds are nil.
+func sample(buf *cellbuf.Buffer, x, y int) *cellbuf.Cell {
+	if x < 0 || y < 0 || x >= buf.Width() || y >= buf.Height() {
+		return nil
+	}
+
+	c := buf.Cell(x, y)
+	if c == nil {
+		blank := cellbuf.BlankCell
+		return &blank
+	}
+	if c.Width != 1 {
+		// Part of a wide character, which does not fit in a single column
+		blank := cellbuf.BlankCell
+		blank.Style = c.Style
+		return &blank
+	}
+	return c
+}
+
+// sampleFrames builds a grid of the size of prev by calling cell for every
+// position, each returning a single column wide cell.
+func sampleFrames(prev *cellbuf.Buffer, cell func(x, y int) *cellbuf.Cell) *cellbuf.Buffer {
+	out := cellbuf.NewBuffer(prev.Width(), prev.Height())
+	for y := range out.Height() {
+		for x := range out.Width() {
+			if c := cell(x, y); c != nil {
+				out.SetCell(x, y, c)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package transitions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestBlend(t *testing.T) {
+	black, white := ansi.TrueColor(0x000000), ansi.TrueColor(0xffffff)
+
+	tests := []struct {
+		t    float64
+		want ansi.TrueColor
+	}{
+		{t: 0, want: 0x000000},
+		{t: 0.5, want: 0x808080},
+		{t: 1, want: 0xffffff},
+		{t: 2, want: 0xffffff},
+	}
+
+	for _, tt := range tests {
+		if got := blend(black, white, tt.t); got != tt.want {
+			t.Errorf("blend(black, white, %v) = %06x, want %06x", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestFadeView(t *testing.T) {
+	prev := "\x1b[38;2;255;0;0mab\x1b[0m\ncd"
+	next := "\x1b[38;2;0;0;255mxy\x1b[0m\nzw"
+
+	f := newFade(Params{}).WithBackground(ansi.TrueColor(0x000000)).(fade)
+
+	f.progress = 0
+	if got := ansi.Strip(f.View(prev, next)); got != "ab\ncd" {
+		t.Errorf("fade at start = %q, want the previous slide", got)
+	}
+
+	f.progress = 0.25
+	got := f.View(prev, next)
+	if ansi.Strip(got) != "ab\ncd" {
+		t.Errorf("fade halfway out = %q, want the previous slide", ansi.Strip(got))
+	}
+	// Red halfway to black
+	if !strings.Contains(got, "38;2;128;0;0") {
+		t.Errorf("fade halfway out = %q, want the red dimmed", got)
+	}
+
+	f.progress = 1
+	if got := ansi.Strip(f.View(prev, next)); got != "xy\nzw" {
+		t.Errorf("fade at end = %q, want the next slide", got)
+	}
+}
+
+func TestDissolveView(t *testing.T) {
+	prev := "aaaa\naaaa"
+	next := "bbbb\nbbbb"
+
+	d := newDissolve(Params{})
+
+	for _, progress := range []float64{0, 0.3, 0.7, 1} {
+		d.progress = progress
+		got := d.View(prev, next)
+		if got != d.View(prev, next) {
+			t.Errorf("dissolve at %v is not stable between frames", progress)
+		}
+
+		switched := strings.Count(got, "b")
+		if progress == 0 && switched != 0 || progress == 1 && switched != 8 {
+			t.Errorf("dissolve at %v = %q", progress, got)
+		}
+	}
+}
+
+func TestMixFramesWideCharacters(t *testing.T) {
+	prev, next := parseFrames("日本", "abcd")
+
+	for x := range 4 {
+		got := renderFrame(mixFrames(prev, next, func(cx, _ int) bool { return cx == x }))
+		if w := ansi.StringWidth(got); w != 4 {
+			t.Errorf("mixing column %d = %q, width %d, want 4", x, got, w)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package transitions
+
+import (
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/harmonica"
+	"github.com/charmbracelet/x/cellbuf"
+)
+
+// cube turns the slides as two faces of a cube rotating around its vertical
+// axis, the next slide coming in from the right, or from the left when
+// rotating right.
+type cube struct {
+	right     bool
+	fps       int
+	params    Params
+	spring    harmonica.Spring
+	progress  float64
+	vel       float64
+	animating bool
+	direction direction
+}
+
+func newCube(right bool, params Params) cube {
+	const frequency = 5.0
+	const damping = 0.9
+
+	p := params.orDefaults(frequency, damping)
+
+	return cube{
+		right:  right,
+		fps:    p.Fps,
+		params: params,
+		spring: harmonica.NewSpring(harmonica.FPS(p.Fps), p.Frequency, p.Damping),
+	}
+}
+
+func (t cube) Start(_, _ int, direction direction) Transition {
+	t.animating = true
+	t.progress = 0
+	t.vel = 0
+	t.direction = direction
+	return t
+}
+
+func (t cube) Animating() bool {
+	return t.animating
+}
+
+func (t cube) Update() (Transition, tea.Cmd) {
+	t.progress, t.vel = t.spring.Update(t.progress, t.vel, 1)
+
+	if t.progress >= 1 {
+		t.animating = false
+		return t, nil
+	}
+
+	return t, Animate(time.Duration(t.fps))
+}
+
+func (t cube) View(prev, next string) string {
+	prevBuf, nextBuf := parseFrames(prev, next)
+
+	// The face on the left of the edge the cube turns around, and the one on
+	// its right
+	left, right := prevBuf, nextBuf
+	angle := min(max(t.progress, 0), 1) * math.Pi / 2
+	leftAngle, rightAngle := angle, math.Pi/2-angle
+	if t.right {
+		left, right = nextBuf, prevBuf
+		leftAngle, rightAngle = rightAngle, leftAngle
+	}
+
+	// Each face is as wide as it faces the viewer
+	width := float64(prevBuf.Width())
+	leftWidth := width * math.Cos(leftAngle) / (math.Cos(leftAngle) + math.Cos(rightAngle))
+	rightWidth := width - leftWidth
+
+	return renderFrame(sampleFrames(prevBuf, func(x, y int) *cellbuf.Cell {
+		col := float64(x) + 0.5
+		if col < leftWidth {
+			// Away from the edge, towards the left of the screen
+			u := perspective((leftWidth-col)/leftWidth, math.Sin(leftAngle))
+			return sample(left, int(width-u*width), y)
+		}
+		u := perspective((col-leftWidth)/rightWidth, math.Sin(rightAngle))
+		return sample(right, int(u*width), y)
+	}))
+}
+
+// perspective maps a position on screen across a face turned away from the
+// viewer, from 0 on the edge of the cube closest to them to 1, to a position
+// on the face. The further the face is turned, the more its far side is
+// compressed.
+func perspective(u, turned float64) float64 {
+	return u / (1 + turned*(1-u))
+}
+
+func (t cube) Name() string {
+	if t.right {
+		return "cubeRight"
+	}
+	return "cubeLeft"
+}
+
+func (t cube) Opposite() Transition {
+	return newCube(!t.right, t.params)
+}
+
+func (t cube) Direction() direction {
+	return t.direction
+}
+
+func (t cube) Fps() int {
+	return t.fps
+}
@@ -0,0 +1,93 @@
+package transitions
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/harmonica"
+)
+
+// dissolve switches the cells of the slide being left over to the next slide
+// one by one, in a random order.
+type dissolve struct {
+	fps       int
+	params    Params
+	spring    harmonica.Spring
+	progress  float64
+	vel       float64
+	animating bool
+	direction direction
+}
+
+func newDissolve(params Params) dissolve {
+	const frequency = 4.0
+	const damping = 0.9
+
+	p := params.orDefaults(frequency, damping)
+
+	return dissolve{
+		fps:    p.Fps,
+		params: params,
+		spring: harmonica.NewSpring(harmonica.FPS(p.Fps), p.Frequency, p.Damping),
+	}
+}
+
+func (t dissolve) Start(_, _ int, direction direction) Transition {
+	t.animating = true
+	t.progress = 0
+	t.vel = 0
+	t.direction = direction
+	return t
+}
+
+func (t dissolve) Animating() bool {
+	return t.animating
+}
+
+func (t dissolve) Update() (Transition, tea.Cmd) {
+	t.progress, t.vel = t.spring.Update(t.progress, t.vel, 1)
+
+	if t.progress >= 1 {
+		t.animating = false
+		return t, nil
+	}
+
+	return t, Animate(time.Duration(t.fps))
+}
+
+func (t dissolve) View(prev, next string) string {
+	prevBuf, nextBuf := parseFrames(prev, next)
+
+	return renderFrame(mixFrames(prevBuf, nextBuf, func(x, y int) bool {
+		return cellOrder(x, y) < t.progress
+	}))
+}
+
+// cellOrder returns when the cell at x, y switches over, between 0 and 1. It
+// hashes the position so that the order looks random but stays the same from
+// one frame to the next.
+func cellOrder(x, y int) float64 {
+	h := uint32(x)*0x9e3779b1 ^ uint32(y)*0x85ebca6b
+	h ^= h >> 16
+	h *= 0x7feb352d
+	h ^= h >> 15
+	h *= 0x846ca68b
+	h ^= h >> 16
+	return float64(h) / (1 << 32)
+}
+
+func (t dissolve) Name() string {
+	return "dissolve"
+}
+
+func (t dissolve) Opposite() Transition {
+	return newDissolve(t.params)
+}
+
+func (t dissolve) Direction() direction {
+	return t.direction
+}
+
+func (t dissolve) Fps() int {
+	return t.fps
+}
@@ -0,0 +1,89 @@
+package transitions
+
+import (
+	"image/color"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/harmonica"
+)
+
+// fade dims the colors of the slide being left into the background, then
+// brings those of the next slide up from it.
+type fade struct {
+	fps        int
+	params     Params
+	spring     harmonica.Spring
+	background color.Color
+	progress   float64
+	vel        float64
+	animating  bool
+	direction  direction
+}
+
+func newFade(params Params) fade {
+	const frequency = 4.0
+	const damping = 0.9
+
+	p := params.orDefaults(frequency, damping)
+
+	return fade{
+		fps:        p.Fps,
+		params:     params,
+		spring:     harmonica.NewSpring(harmonica.FPS(p.Fps), p.Frequency, p.Damping),
+		background: defaultBackground,
+	}
+}
+
+func (t fade) Start(_, _ int, direction direction) Transition {
+	t.animating = true
+	t.progress = 0
+	t.vel = 0
+	t.direction = direction
+	return t
+}
+
+func (t fade) Animating() bool {
+	return t.animating
+}
+
+func (t fade) Update() (Transition, tea.Cmd) {
+	t.progress, t.vel = t.spring.Update(t.progress, t.vel, 1)
+
+	if t.progress >= 1 {
+		t.animating = false
+		return t, nil
+	}
+
+	return t, Animate(time.Duration(t.fps))
+}
+
+func (t fade) View(prev, next string) string {
+	prevBuf, nextBuf := parseFrames(prev, next)
+
+	if t.progress < 0.5 {
+		return renderFrame(fadeFrame(prevBuf, t.background, t.progress*2))
+	}
+	return renderFrame(fadeFrame(nextBuf, t.background, (1-t.progress)*2))
+}
+
+func (t fade) WithBackground(background color.Color) Transition {
+	t.background = background
+	return t
+}
+
+func (t fade) Name() string {
+	return "fade"
+}
+
+func (t fade) Opposite() Transition {
+	return newFade(t.params).WithBackground(t.background)
+}
+
+func (t fade) Direction() direction {
+	return t.direction
+}
+
+func (t fade) Fps() int {
+	return t.fps
+}
@@ -0,0 +1,110 @@
+package transitions
+
+import (
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/harmonica"
+	"github.com/muesli/reflow/wordwrap"
+
+	"github.com/museslabs/kyma/internal/skip"
+)
+
+type flipLeft struct {
+	width     int
+	fps       int
+	params    Params
+	spring    harmonica.Spring
+	x         float64
+	xVel      float64
+	animating bool
+	direction direction
+}
+
+func newFlipLeft(params Params) flipLeft {
+	const frequency = 7.0
+	const damping = 0.8
+
+	p := params.orDefaults(frequency, damping)
+
+	return flipLeft{
+		fps:    p.Fps,
+		params: params,
+		spring: harmonica.NewSpring(harmonica.FPS(p.Fps), p.Frequency, p.Damping),
+	}
+}
+
+func (t flipLeft) Start(width int, _ int, direction direction) Transition {
+	t.width = width
+	t.animating = true
+	t.x = 0
+	t.xVel = 0
+	t.direction = direction
+	return t
+}
+
+func (t flipLeft) Animating() bool {
+	return t.animating
+}
+
+func (t flipLeft) Update() (Transition, tea.Cmd) {
+	targetX := float64(t.width)
+
+	t.x, t.xVel = t.spring.Update(t.x, t.xVel, targetX)
+
+	if t.x >= targetX {
+		t.animating = false
+		return t, nil
+	}
+
+	return t, Animate(time.Duration(t.fps))
+}
+
+func (t flipLeft) View(prev string, next string) string {
+	var s strings.Builder
+
+	// Columns of the previous slide left on screen
+	x := max(t.width-int(math.Round(t.x)), 0)
+
+	prevLines := strings.Split(prev, "\n")
+	nextLines := strings.Split(next, "\n")
+
+	// assert that slides are always equal height
+	if len(nextLines) != len(prevLines) {
+		slog.Error("Slides of not equal height")
+		return next
+	}
+
+	for i := range nextLines {
+		prev = ""
+		if x > 0 {
+			prev = strings.Split(wordwrap.String(prevLines[i], x), "\n")[0]
+		}
+		next := skip.String(nextLines[i], uint(x))
+		s.WriteString(prev + " " + next)
+		if i < len(nextLines)-1 {
+			s.WriteString("\n")
+		}
+	}
+
+	return s.String()
+}
+
+func (t flipLeft) Name() string {
+	return "flipLeft"
+}
+
+func (t flipLeft) Opposite() Transition {
+	return newFlipRight(t.params)
+}
+
+func (t flipLeft) Direction() direction {
+	return t.direction
+}
+
+func (t flipLeft) Fps() int {
+	return t.fps
+}
@@ -15,6 +15,7 @@ import (
 type flipRight struct {
 	width     int
 	fps       int
+	params    Params
 	spring    harmonica.Spring
 	x         float64
 	xVel      float64
@@ -22,13 +23,16 @@ type flipRight struct {
 	direction direction
 }
 
-func newFlipRight(fps int) flipRight {
+func newFlipRight(params Params) flipRight {
 	const frequency = 7.0
 	const damping = 0.8
 
+	p := params.orDefaults(frequency, damping)
+
 	return flipRight{
-		fps:    fps,
-		spring: harmonica.NewSpring(harmonica.FPS(fps), frequency, damping),
+		fps:    p.Fps,
+		params: params,
+		spring: harmonica.NewSpring(harmonica.FPS(p.Fps), p.Frequency, p.Damping),
 	}
 }
 
@@ -90,9 +94,13 @@ func (t flipRight) Name() string {
 }
 
 func (t flipRight) Opposite() Transition {
-	return t
+	return newFlipLeft(t.params)
 }
 
 func (t flipRight) Direction() direction {
 	return t.direction
 }
+
+func (t flipRight) Fps() int {
+	return t.fps
+}
@@ -0,0 +1,79 @@
+package transitions
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// ReducedMotion is how transitions are toned down for recordings, slow
+// terminals and viewers sensitive to motion.
+type ReducedMotion string
+
+const (
+	// ReducedMotionAuto plays transitions as configured, and none while
+	// slides take too long to render for their frames not to stutter. What
+	// renders slides measures it, [Reduce] leaving transitions as they are.
+	ReducedMotionAuto ReducedMotion = "auto"
+	// ReducedMotionOff always plays transitions as configured.
+	ReducedMotionOff ReducedMotion = "off"
+	// ReducedMotionFade replaces every transition with a short fade.
+	ReducedMotionFade ReducedMotion = "fade"
+	// ReducedMotionNone replaces every transition with none.
+	ReducedMotionNone ReducedMotion = "none"
+)
+
+// shortFade is the frequency of the spring of the fade reduced motion
+// replaces transitions with, twice as fast as the default one.
+const shortFade = 8.0
+
+var reducedMotion atomic.Value
+
+// ParseReducedMotion returns the reduced motion mode named s. An empty string
+// is [ReducedMotionOff].
+func ParseReducedMotion(s string) (ReducedMotion, error) {
+	switch r := ReducedMotion(s); r {
+	case "":
+		return ReducedMotionOff, nil
+	case ReducedMotionAuto, ReducedMotionOff, ReducedMotionFade, ReducedMotionNone:
+		return r, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid reduced motion %q, expected one of %s, %s, %s or %s",
+			s,
+			ReducedMotionAuto,
+			ReducedMotionOff,
+			ReducedMotionFade,
+			ReducedMotionNone,
+		)
+	}
+}
+
+// SetReducedMotion sets how transitions started from now on are toned down.
+func SetReducedMotion(r ReducedMotion) {
+	reducedMotion.Store(r)
+}
+
+// CurrentReducedMotion returns the mode set by [SetReducedMotion],
+// [ReducedMotionOff] by default.
+func CurrentReducedMotion() ReducedMotion {
+	if r, ok := reducedMotion.Load().(ReducedMotion); ok {
+		return r
+	}
+	return ReducedMotionOff
+}
+
+// Reduce returns the transition to play instead of t under the current
+// reduced motion mode.
+func Reduce(t Transition) Transition {
+	switch CurrentReducedMotion() {
+	case ReducedMotionFade:
+		if _, ok := t.(noTransition); ok {
+			return t
+		}
+		return newFade(Params{Frequency: shortFade})
+	case ReducedMotionNone:
+		return None()
+	default:
+		return t
+	}
+}
@@ -4,7 +4,7 @@ import tea "github.com/charmbracelet/bubbletea"
 
 type noTransition struct{}
 
-func newNoTransition(_ int) noTransition {
+func newNoTransition(_ Params) noTransition {
 	return noTransition{}
 }
 
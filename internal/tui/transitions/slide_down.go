@@ -12,6 +12,7 @@ import (
 type slideDown struct {
 	height    int
 	fps       int
+	params    Params
 	spring    harmonica.Spring
 	y         float64
 	yVel      float64
@@ -19,13 +20,16 @@ type slideDown struct {
 	direction direction
 }
 
-func newSlideDown(fps int) slideDown {
+func newSlideDown(params Params) slideDown {
 	const frequency = 7.0
 	const damping = 0.8
 
+	p := params.orDefaults(frequency, damping)
+
 	return slideDown{
-		fps:    fps,
-		spring: harmonica.NewSpring(harmonica.FPS(fps), frequency, damping),
+		fps:    p.Fps,
+		params: params,
+		spring: harmonica.NewSpring(harmonica.FPS(p.Fps), p.Frequency, p.Damping),
 	}
 }
 
@@ -80,9 +84,13 @@ func (t slideDown) Name() string {
 }
 
 func (t slideDown) Opposite() Transition {
-	return newSlideUp(t.fps)
+	return newSlideUp(t.params)
 }
 
 func (t slideDown) Direction() direction {
 	return t.direction
 }
+
+func (t slideDown) Fps() int {
+	return t.fps
+}
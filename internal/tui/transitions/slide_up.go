@@ -13,6 +13,7 @@ import (
 type slideUp struct {
 	height    int
 	fps       int
+	params    Params
 	spring    harmonica.Spring
 	y         float64
 	yVel      float64
@@ -20,13 +21,16 @@ type slideUp struct {
 	direction direction
 }
 
-func newSlideUp(fps int) slideUp {
+func newSlideUp(params Params) slideUp {
 	const frequency = 7.0
 	const damping = 0.8
 
+	p := params.orDefaults(frequency, damping)
+
 	return slideUp{
-		fps:    fps,
-		spring: harmonica.NewSpring(harmonica.FPS(fps), frequency, damping),
+		fps:    p.Fps,
+		params: params,
+		spring: harmonica.NewSpring(harmonica.FPS(p.Fps), p.Frequency, p.Damping),
 	}
 }
 
@@ -77,9 +81,13 @@ func (t slideUp) Name() string {
 }
 
 func (t slideUp) Opposite() Transition {
-	return newSlideDown(t.fps)
+	return newSlideDown(t.params)
 }
 
 func (t slideUp) Direction() direction {
 	return t.direction
 }
+
+func (t slideUp) Fps() int {
+	return t.fps
+}
@@ -16,6 +16,7 @@ import (
 type swipeLeft struct {
 	width     int
 	fps       int
+	params    Params
 	spring    harmonica.Spring
 	x         float64
 	xVel      float64
@@ -23,13 +24,16 @@ type swipeLeft struct {
 	direction direction
 }
 
-func newSwipeLeft(fps int) swipeLeft {
+func newSwipeLeft(params Params) swipeLeft {
 	const frequency = 7.0
 	const damping = 0.75
 
+	p := params.orDefaults(frequency, damping)
+
 	return swipeLeft{
-		fps:    fps,
-		spring: harmonica.NewSpring(harmonica.FPS(fps), frequency, damping),
+		fps:    p.Fps,
+		params: params,
+		spring: harmonica.NewSpring(harmonica.FPS(p.Fps), p.Frequency, p.Damping),
 	}
 }
 
@@ -89,9 +93,13 @@ func (t swipeLeft) Name() string {
 }
 
 func (t swipeLeft) Opposite() Transition {
-	return newSwipeRight(t.fps)
+	return newSwipeRight(t.params)
 }
 
 func (t swipeLeft) Direction() direction {
 	return t.direction
 }
+
+func (t swipeLeft) Fps() int {
+	return t.fps
+}
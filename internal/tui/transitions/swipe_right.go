@@ -16,6 +16,7 @@ import (
 type swipeRight struct {
 	width     int
 	fps       int
+	params    Params
 	spring    harmonica.Spring
 	x         float64
 	xVel      float64
@@ -23,13 +24,16 @@ type swipeRight struct {
 	direction direction
 }
 
-func newSwipeRight(fps int) swipeRight {
+func newSwipeRight(params Params) swipeRight {
 	const frequency = 7.0
 	const damping = 0.75
 
+	p := params.orDefaults(frequency, damping)
+
 	return swipeRight{
-		fps:    fps,
-		spring: harmonica.NewSpring(harmonica.FPS(fps), frequency, damping),
+		fps:    p.Fps,
+		params: params,
+		spring: harmonica.NewSpring(harmonica.FPS(p.Fps), p.Frequency, p.Damping),
 	}
 }
 
@@ -89,9 +93,13 @@ func (t swipeRight) Name() string {
 }
 
 func (t swipeRight) Opposite() Transition {
-	return newSwipeLeft(t.fps)
+	return newSwipeLeft(t.params)
 }
 
 func (t swipeRight) Direction() direction {
 	return t.direction
 }
+
+func (t swipeRight) Fps() int {
+	return t.fps
+}
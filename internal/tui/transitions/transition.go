@@ -2,6 +2,10 @@ package transitions
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,7 +23,6 @@ type FrameMsg time.Time
 const Fps = 60
 
 func Animate(fps time.Duration) tea.Cmd {
-	fmt.Print("\x1b_Ga=d\x1b\\")
 	return tea.Tick(time.Second/fps, func(t time.Time) tea.Msg {
 		return FrameMsg(t)
 	})
@@ -35,19 +38,136 @@ type Transition interface {
 	Direction() direction
 }
 
-func Get(name string, fps int) Transition {
-	switch name {
-	case "slideUp":
-		return newSlideUp(fps)
-	case "slideDown":
-		return newSlideDown(fps)
-	case "swipeLeft":
-		return newSwipeLeft(fps)
-	case "swipeRight":
-		return newSwipeRight(fps)
-	case "flip":
-		return newFlipRight(fps)
-	default:
-		return newNoTransition(fps)
+// FrameRate returns the frames per second t is played at, [Fps] unless it
+// has a frame rate of its own.
+func FrameRate(t Transition) int {
+	if f, ok := t.(interface{ Fps() int }); ok && f.Fps() > 0 {
+		return f.Fps()
 	}
+	return Fps
+}
+
+// MaxFps is the highest frame rate a transition can be played at.
+const MaxFps = 240
+
+// Params tune the spring animating a transition and the frame rate it is
+// played at. Zero values keep the defaults of the transition.
+type Params struct {
+	Frequency float64
+	Damping   float64
+	Fps       int
+}
+
+func (p Params) Validate() error {
+	if p.Frequency < 0 {
+		return fmt.Errorf("frequency must be positive, got %v", p.Frequency)
+	}
+	// Transitions end once the spring overshoots its target, which springs
+	// damped critically or more never do
+	if p.Damping < 0 || p.Damping >= 1 {
+		return fmt.Errorf("damping must be at least 0 and below 1, got %v", p.Damping)
+	}
+	if p.Fps < 0 || p.Fps > MaxFps {
+		return fmt.Errorf("fps must be between 1 and %d, or 0 for the default, got %d", MaxFps, p.Fps)
+	}
+	return nil
+}
+
+// orDefaults fills the parameters left to zero with the given spring and
+// [Fps].
+func (p Params) orDefaults(frequency, damping float64) Params {
+	if p.Frequency == 0 {
+		p.Frequency = frequency
+	}
+	if p.Damping == 0 {
+		p.Damping = damping
+	}
+	if p.Fps == 0 {
+		p.Fps = Fps
+	}
+	return p
+}
+
+// Factory creates a transition tuned by params.
+type Factory func(params Params) Transition
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{
+		"none":       factory(newNoTransition),
+		"slideUp":    factory(newSlideUp),
+		"slideDown":  factory(newSlideDown),
+		"swipeLeft":  factory(newSwipeLeft),
+		"swipeRight": factory(newSwipeRight),
+		"flip":       factory(newFlipRight),
+		"flipRight":  factory(newFlipRight),
+		"flipLeft":   factory(newFlipLeft),
+		"fade":       factory(newFade),
+		"dissolve":   factory(newDissolve),
+		"zoomIn":     func(params Params) Transition { return newZoom(false, params) },
+		"zoomOut":    func(params Params) Transition { return newZoom(true, params) },
+		"wipeLeft":   func(params Params) Transition { return newWipe(sideLeft, params) },
+		"wipeRight":  func(params Params) Transition { return newWipe(sideRight, params) },
+		"wipeUp":     func(params Params) Transition { return newWipe(sideUp, params) },
+		"wipeDown":   func(params Params) Transition { return newWipe(sideDown, params) },
+		"cube":       func(params Params) Transition { return newCube(false, params) },
+		"cubeLeft":   func(params Params) Transition { return newCube(false, params) },
+		"cubeRight":  func(params Params) Transition { return newCube(true, params) },
+	}
+)
+
+// factory turns the constructor of a built-in transition into a [Factory].
+func factory[T Transition](constructor func(Params) T) Factory {
+	return func(params Params) Transition {
+		return constructor(params)
+	}
+}
+
+// Register makes a transition available under name, to be used in front
+// matter and configuration like the built-in ones. The Opposite of the
+// transitions it creates is played when moving backwards. It panics if name
+// is empty or already registered, or if factory is nil.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if name == "" {
+		panic("transitions: Register with an empty name")
+	}
+	if factory == nil {
+		panic("transitions: Register factory is nil for " + name)
+	}
+	if _, ok := registry[name]; ok {
+		panic("transitions: Register called twice for " + name)
+	}
+	registry[name] = factory
+}
+
+// Names returns the names of the registered transitions, sorted.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return slices.Sorted(maps.Keys(registry))
+}
+
+// Get returns the transition registered under name, tuned by params.
+func Get(name string, params Params) (Transition, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf(
+			"unknown transition %q, available transitions are: %s",
+			name,
+			strings.Join(Names(), ", "),
+		)
+	}
+	return factory(params), nil
+}
+
+// None returns the transition showing the next slide right away.
+func None() Transition {
+	return newNoTransition(Params{})
 }
@@ -0,0 +1,275 @@
+package transitions
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestOpposite(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "swipeLeft", want: "swipeRight"},
+		{name: "slideUp", want: "slideDown"},
+		{name: "flip", want: "flipLeft"},
+		{name: "flipLeft", want: "flipRight"},
+		{name: "zoomIn", want: "zoomOut"},
+		{name: "zoomOut", want: "zoomIn"},
+		{name: "wipeLeft", want: "wipeRight"},
+		{name: "wipeUp", want: "wipeDown"},
+		{name: "cube", want: "cubeRight"},
+		{name: "cubeRight", want: "cubeLeft"},
+	}
+
+	for _, tt := range tests {
+		transition, err := Get(tt.name, Params{})
+		if err != nil {
+			t.Fatalf("Get(%q) error = %v", tt.name, err)
+		}
+		if got := transition.Opposite().Name(); got != tt.want {
+			t.Errorf("Get(%q).Opposite() = %q, want %q", tt.name, got, tt.want)
+		}
+		if got := transition.Opposite().Opposite().Name(); got != transition.Name() {
+			t.Errorf("Get(%q).Opposite().Opposite() = %q, want %q", tt.name, got, transition.Name())
+		}
+	}
+}
+
+func TestGetUnknown(t *testing.T) {
+	_, err := Get("spin", Params{})
+	if err == nil {
+		t.Fatal("Get() with an unknown name should fail")
+	}
+	if !strings.Contains(err.Error(), "swipeLeft") {
+		t.Errorf("Get() error = %v, want the available transitions", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Cleanup(func() {
+		registryMu.Lock()
+		delete(registry, "test")
+		registryMu.Unlock()
+	})
+
+	var got Params
+	Register("test", func(params Params) Transition {
+		got = params
+		return newFade(params)
+	})
+
+	if !slices.Contains(Names(), "test") {
+		t.Errorf("Names() = %v, want the registered transition", Names())
+	}
+
+	transition, err := Get("test", Params{Fps: 30})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if transition.Name() != "fade" || got.Fps != 30 {
+		t.Errorf("Get() = %v with %+v, want the registered transition", transition.Name(), got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register() twice with the same name should panic")
+		}
+	}()
+	Register("test", func(params Params) Transition { return None() })
+}
+
+func TestFlipLeftView(t *testing.T) {
+	f := newFlipLeft(Params{}).Start(4, 1, Forwards).(flipLeft)
+
+	f.x = 0
+	if got := f.View("abcd", "wxyz"); got != "abcd " {
+		t.Errorf("flipLeft at start = %q, want the previous slide", got)
+	}
+
+	f.x = 4
+	if got := f.View("abcd", "wxyz"); got != " wxyz" {
+		t.Errorf("flipLeft at end = %q, want the next slide", got)
+	}
+}
+
+func TestWipeView(t *testing.T) {
+	prev := "aaaa\naaaa"
+	next := "bbbb\nbbbb"
+
+	tests := []struct {
+		towards side
+		want    string
+	}{
+		{towards: sideLeft, want: "aabb\naabb"},
+		{towards: sideRight, want: "bbaa\nbbaa"},
+		{towards: sideUp, want: "aaaa\nbbbb"},
+		{towards: sideDown, want: "bbbb\naaaa"},
+	}
+
+	for _, tt := range tests {
+		w := newWipe(tt.towards, Params{})
+		w.progress = 0.5
+		if got := ansi.Strip(w.View(prev, next)); got != tt.want {
+			t.Errorf("%s halfway = %q, want %q", w.Name(), got, tt.want)
+		}
+	}
+}
+
+func TestZoomView(t *testing.T) {
+	prev := "aaaa\naaaa\naaaa\naaaa"
+	next := "bbbb\nbbbb\nbbbb\nbbbb"
+
+	for _, out := range []bool{false, true} {
+		z := newZoom(out, Params{})
+
+		z.progress = 0
+		start := ansi.Strip(z.View(prev, next))
+		if start != prev {
+			t.Errorf("%s at start = %q, want the previous slide", z.Name(), start)
+		}
+
+		z.progress = 0.5
+		half := ansi.Strip(z.View(prev, next))
+		if n := strings.Count(half, "b"); n == 0 || n == 16 {
+			t.Errorf("%s halfway = %q, want both slides", z.Name(), half)
+		}
+
+		z.progress = 1
+		if got := ansi.Strip(z.View(prev, next)); got != next {
+			t.Errorf("%s at end = %q, want the next slide", z.Name(), got)
+		}
+	}
+}
+
+func TestCubeView(t *testing.T) {
+	prev := "abcdefgh"
+	next := "ABCDEFGH"
+
+	for _, right := range []bool{false, true} {
+		c := newCube(right, Params{})
+
+		c.progress = 0
+		if got := ansi.Strip(c.View(prev, next)); got != prev {
+			t.Errorf("%s at start = %q, want the previous slide", c.Name(), got)
+		}
+
+		c.progress = 0.5
+		got := ansi.Strip(c.View(prev, next))
+		if ansi.StringWidth(got) != 8 {
+			t.Errorf("%s halfway = %q, want 8 columns", c.Name(), got)
+		}
+		if strings.ToLower(got) == got || strings.ToUpper(got) == got {
+			t.Errorf("%s halfway = %q, want both slides", c.Name(), got)
+		}
+
+		c.progress = 1
+		if got := ansi.Strip(c.View(prev, next)); got != next {
+			t.Errorf("%s at end = %q, want the next slide", c.Name(), got)
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	t.Cleanup(func() { SetReducedMotion(ReducedMotionOff) })
+
+	swipe, err := Get("swipeLeft", Params{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	tests := []struct {
+		mode ReducedMotion
+		want string
+	}{
+		{mode: ReducedMotionAuto, want: "swipeLeft"},
+		{mode: ReducedMotionOff, want: "swipeLeft"},
+		{mode: ReducedMotionFade, want: "fade"},
+		{mode: ReducedMotionNone, want: "none"},
+	}
+
+	for _, tt := range tests {
+		SetReducedMotion(tt.mode)
+		if got := Reduce(swipe).Name(); got != tt.want {
+			t.Errorf("Reduce() with %s = %q, want %q", tt.mode, got, tt.want)
+		}
+	}
+
+	SetReducedMotion(ReducedMotionFade)
+	if got := Reduce(None()).Name(); got != "none" {
+		t.Errorf("Reduce(None()) with fade = %q, want none", got)
+	}
+}
+
+func TestParseReducedMotion(t *testing.T) {
+	if got, err := ParseReducedMotion(""); err != nil || got != ReducedMotionOff {
+		t.Errorf("ParseReducedMotion(\"\") = %q, %v, want off", got, err)
+	}
+	if got, err := ParseReducedMotion("none"); err != nil || got != ReducedMotionNone {
+		t.Errorf("ParseReducedMotion(\"none\") = %q, %v, want none", got, err)
+	}
+	if _, err := ParseReducedMotion("slow"); err == nil {
+		t.Error("ParseReducedMotion() with an unknown mode should fail")
+	}
+}
+
+func TestParamsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  Params
+		wantErr bool
+	}{
+		{name: "defaults", params: Params{}},
+		{name: "tuned", params: Params{Frequency: 5, Damping: 0.99, Fps: MaxFps}},
+		{name: "negative frequency", params: Params{Frequency: -1}, wantErr: true},
+		{name: "negative damping", params: Params{Damping: -0.5}, wantErr: true},
+		{name: "critical damping", params: Params{Damping: 1}, wantErr: true},
+		{name: "overdamped", params: Params{Damping: 3}, wantErr: true},
+		{name: "negative fps", params: Params{Fps: -1}, wantErr: true},
+		{name: "fps too high", params: Params{Fps: MaxFps + 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransitionsEnd(t *testing.T) {
+	// The most damped spring allowed still ends every transition
+	params := Params{Damping: 0.99}
+	for _, name := range Names() {
+		transition, err := Get(name, params)
+		if err != nil {
+			t.Fatalf("Get(%q) error = %v", name, err)
+		}
+
+		transition = transition.Start(80, 24, Forwards)
+		for i := 0; transition.Animating(); i++ {
+			if i > 10*Fps {
+				t.Errorf("%s still animating after %d frames", name, i)
+				break
+			}
+			transition, _ = transition.Update()
+		}
+	}
+}
+
+func TestFrameRate(t *testing.T) {
+	fade, err := Get("fade", Params{Fps: 30})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := FrameRate(fade); got != 30 {
+		t.Errorf("FrameRate() = %d, want 30", got)
+	}
+	if got := FrameRate(None()); got != Fps {
+		t.Errorf("FrameRate(None()) = %d, want %d", got, Fps)
+	}
+}
@@ -0,0 +1,119 @@
+package transitions
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/harmonica"
+)
+
+// side is the edge of the screen a wipe moves towards.
+type side byte
+
+const (
+	sideLeft side = iota
+	sideRight
+	sideUp
+	sideDown
+)
+
+// wipe reveals the next slide behind a hard edge moving across the screen,
+// without moving either slide.
+type wipe struct {
+	towards   side
+	fps       int
+	params    Params
+	spring    harmonica.Spring
+	progress  float64
+	vel       float64
+	animating bool
+	direction direction
+}
+
+func newWipe(towards side, params Params) wipe {
+	const frequency = 5.0
+	const damping = 0.9
+
+	p := params.orDefaults(frequency, damping)
+
+	return wipe{
+		towards: towards,
+		fps:     p.Fps,
+		params:  params,
+		spring:  harmonica.NewSpring(harmonica.FPS(p.Fps), p.Frequency, p.Damping),
+	}
+}
+
+func (t wipe) Start(_, _ int, direction direction) Transition {
+	t.animating = true
+	t.progress = 0
+	t.vel = 0
+	t.direction = direction
+	return t
+}
+
+func (t wipe) Animating() bool {
+	return t.animating
+}
+
+func (t wipe) Update() (Transition, tea.Cmd) {
+	t.progress, t.vel = t.spring.Update(t.progress, t.vel, 1)
+
+	if t.progress >= 1 {
+		t.animating = false
+		return t, nil
+	}
+
+	return t, Animate(time.Duration(t.fps))
+}
+
+func (t wipe) View(prev, next string) string {
+	prevBuf, nextBuf := parseFrames(prev, next)
+
+	width, height := float64(prevBuf.Width()), float64(prevBuf.Height())
+	progress := min(max(t.progress, 0), 1)
+
+	return renderFrame(mixFrames(prevBuf, nextBuf, func(x, y int) bool {
+		switch t.towards {
+		case sideLeft:
+			return float64(x) >= width*(1-progress)
+		case sideRight:
+			return float64(x) < width*progress
+		case sideUp:
+			return float64(y) >= height*(1-progress)
+		default:
+			return float64(y) < height*progress
+		}
+	}))
+}
+
+func (t wipe) Name() string {
+	switch t.towards {
+	case sideLeft:
+		return "wipeLeft"
+	case sideRight:
+		return "wipeRight"
+	case sideUp:
+		return "wipeUp"
+	default:
+		return "wipeDown"
+	}
+}
+
+func (t wipe) Opposite() Transition {
+	opposite := map[side]side{
+		sideLeft:  sideRight,
+		sideRight: sideLeft,
+		sideUp:    sideDown,
+		sideDown:  sideUp,
+	}
+	return newWipe(opposite[t.towards], t.params)
+}
+
+func (t wipe) Direction() direction {
+	return t.direction
+}
+
+func (t wipe) Fps() int {
+	return t.fps
+}
@@ -0,0 +1,107 @@
+package transitions
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/harmonica"
+	"github.com/charmbracelet/x/cellbuf"
+)
+
+// zoom scales the next slide up from the center over the slide being left,
+// or, zooming out, scales the slide being left down into the center to reveal
+// the next one.
+type zoom struct {
+	out       bool
+	fps       int
+	params    Params
+	spring    harmonica.Spring
+	progress  float64
+	vel       float64
+	animating bool
+	direction direction
+}
+
+func newZoom(out bool, params Params) zoom {
+	const frequency = 5.0
+	const damping = 0.9
+
+	p := params.orDefaults(frequency, damping)
+
+	return zoom{
+		out:    out,
+		fps:    p.Fps,
+		params: params,
+		spring: harmonica.NewSpring(harmonica.FPS(p.Fps), p.Frequency, p.Damping),
+	}
+}
+
+func (t zoom) Start(_, _ int, direction direction) Transition {
+	t.animating = true
+	t.progress = 0
+	t.vel = 0
+	t.direction = direction
+	return t
+}
+
+func (t zoom) Animating() bool {
+	return t.animating
+}
+
+func (t zoom) Update() (Transition, tea.Cmd) {
+	t.progress, t.vel = t.spring.Update(t.progress, t.vel, 1)
+
+	if t.progress >= 1 {
+		t.animating = false
+		return t, nil
+	}
+
+	return t, Animate(time.Duration(t.fps))
+}
+
+func (t zoom) View(prev, next string) string {
+	prevBuf, nextBuf := parseFrames(prev, next)
+
+	// The scaled frame is drawn over the other one
+	scaled, under, scale := nextBuf, prevBuf, t.progress
+	if t.out {
+		scaled, under, scale = prevBuf, nextBuf, 1-t.progress
+	}
+	scale = min(max(scale, 0), 1)
+
+	cx, cy := float64(prevBuf.Width())/2, float64(prevBuf.Height())/2
+
+	return renderFrame(sampleFrames(prevBuf, func(x, y int) *cellbuf.Cell {
+		if scale > 0 {
+			// Pick the cell of the scaled frame displayed here, if any,
+			// sampling the nearest one
+			sx := cx + (float64(x)+0.5-cx)/scale
+			sy := cy + (float64(y)+0.5-cy)/scale
+			if sx >= 0 && sy >= 0 {
+				if c := sample(scaled, int(sx), int(sy)); c != nil {
+					return c
+				}
+			}
+		}
+		return sample(under, x, y)
+	}))
+}
+
+func (t zoom) Name() string {
+	if t.out {
+		return "zoomOut"
+	}
+	return "zoomIn"
+}
+
+func (t zoom) Opposite() Transition {
+	return newZoom(!t.out, t.params)
+}
+
+func (t zoom) Direction() direction {
+	return t.direction
+}
+
+func (t zoom) Fps() int {
+	return t.fps
+}
@@ -1,9 +1,9 @@
 package tui
 
 import (
-	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -11,6 +11,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/museslabs/kyma/internal/config"
+	"github.com/museslabs/kyma/internal/img"
+	"github.com/museslabs/kyma/internal/markdown"
 	"github.com/museslabs/kyma/internal/tui/transitions"
 )
 
@@ -24,6 +26,20 @@ type keyMap struct {
 	GoTo    key.Binding
 	Jump    key.Binding
 	Timer   key.Binding
+
+	NextSection key.Binding
+	PrevSection key.Binding
+	Links       key.Binding
+	Pointer     key.Binding
+	Annotate    key.Binding
+	Save        key.Binding
+	Blackout    key.Binding
+	Whiteout    key.Binding
+	Freeze      key.Binding
+	Back        key.Binding
+	Forward     key.Binding
+	SetMark     key.Binding
+	GoToMark    key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
@@ -71,6 +87,59 @@ var keys = keyMap{
 		key.WithKeys("t"),
 		key.WithHelp("t", "toggle timer"),
 	),
+	NextSection: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next section"),
+	),
+	PrevSection: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "previous section"),
+	),
+	Links: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "follow link"),
+	),
+	Pointer: key.NewBinding(
+		key.WithKeys("."),
+		key.WithHelp(".", "laser pointer"),
+	),
+	Annotate: key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "annotate"),
+	),
+	Save: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "save annotations"),
+	),
+	Blackout: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "blackout"),
+	),
+	Whiteout: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "whiteout"),
+	),
+	Freeze: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "freeze"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("ctrl+o", "back in history"),
+	),
+	Forward: key.NewBinding(
+		// Terminals send ctrl+i as tab
+		key.WithKeys("ctrl+i", "tab"),
+		key.WithHelp("ctrl+i, tab", "forward in history"),
+	),
+	SetMark: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "set mark"),
+	),
+	GoToMark: key.NewBinding(
+		key.WithKeys("'", "`"),
+		key.WithHelp("', `", "go to mark"),
+	),
 }
 
 func style(width, height int, styleConfig config.StyleConfig) config.SlideStyle {
@@ -92,6 +161,166 @@ func (m *model) navigateToSlide(newSlide *Slide) {
 	m.syncCurrentSlide()
 }
 
+// jumpTo moves to slide, recording the slide left in the jump list.
+func (m *model) jumpTo(slide *Slide) tea.Cmd {
+	if slide == nil || slide == m.slide {
+		return nil
+	}
+	m.history = m.history.Push(m.slide.position())
+	return m.moveTo(slide)
+}
+
+// moveTo moves to any slide with a transition: the one of the slides
+// involved when they are next to each other, the jump transition otherwise.
+func (m *model) moveTo(slide *Slide) tea.Cmd {
+	if slide == nil || slide == m.slide {
+		return nil
+	}
+
+	from := m.slide
+	backwards := slide.position() < from.position()
+
+	var transition transitions.Transition
+	switch slide {
+	case from.NextVisible():
+		transition = slide.Properties.Transition
+	case from.PrevVisible():
+		transition = from.Properties.Transition.Opposite()
+	default:
+		transition = m.deck.JumpTransition
+		if transition == nil {
+			transition = transitions.None()
+		}
+		if backwards {
+			transition = transition.Opposite()
+		}
+	}
+
+	m.navigateToSlide(slide)
+	return m.animate(from, transition, backwards)
+}
+
+// animate starts transition from the slide left to the current one.
+func (m *model) animate(from *Slide, transition transitions.Transition, backwards bool) tea.Cmd {
+	// Frames already playing the transition to the slide left or its
+	// entrance animations go on with the transition, rather than a second
+	// loop of frames advancing it twice as fast
+	running := from.ActiveTransition != nil && from.ActiveTransition.Animating() ||
+		from.entrance.Animating()
+
+	// The slide left may itself be transitioning from the current one, which
+	// would render them into each other forever
+	from.ActiveTransition = nil
+
+	direction := transitions.Forwards
+	if backwards {
+		direction = transitions.Backwards
+	}
+
+	transition = m.renders.reduce(transition)
+	// Transitions blending colors fade through the background of the theme
+	transition = transitions.WithBackground(transition, themeBackground(m.slide.Style.Theme))
+
+	from.entrance = from.entrance.finish()
+
+	m.slide.from = from
+	m.slide.ActiveTransition = transition.Start(m.width, m.height, direction)
+	m.slide.enter(backwards)
+	m.slide.prefetch()
+	if running {
+		return nil
+	}
+	return transitions.Animate(time.Duration(transitions.FrameRate(transition)))
+}
+
+const (
+	// slowRender is how long views can take to render on average before
+	// reduced motion auto leaves transitions out, their frames stuttering.
+	slowRender = 50 * time.Millisecond
+	// renderSmoothing is how many views the average render time mostly
+	// spans.
+	renderSmoothing = 4
+)
+
+// renderMeter measures how long views take to render, for reduced motion
+// auto to leave transitions out while rendering is slow. It is shared by the
+// copies of the model, View having a value receiver, and only used from the
+// goroutine updating and viewing it. A nil meter measures nothing.
+type renderMeter struct {
+	// average is the moving average of the time views took to render.
+	average time.Duration
+	slow    bool
+}
+
+// record adds a view taking d to render to the average. Rendering is slow
+// once the average reaches [slowRender], and fast again once it drops under
+// half of it, for transitions not to come and go on every other view.
+func (r *renderMeter) record(d time.Duration) {
+	if r == nil {
+		return
+	}
+
+	r.average += (d - r.average) / renderSmoothing
+	switch {
+	case !r.slow && r.average >= slowRender:
+		r.slow = true
+		slog.Info("Rendering too slow for transitions, leaving them out", "average", r.average)
+	case r.slow && r.average < slowRender/2:
+		r.slow = false
+		slog.Info("Rendering fast again, playing transitions", "average", r.average)
+	}
+}
+
+// reduce returns the transition to play instead of t under the current
+// reduced motion mode, none under auto while rendering is slow.
+func (r *renderMeter) reduce(t transitions.Transition) transitions.Transition {
+	if r != nil && r.slow && transitions.CurrentReducedMotion() == transitions.ReducedMotionAuto {
+		return transitions.None()
+	}
+	return transitions.Reduce(t)
+}
+
+// slideAt returns the slide at position in the deck, skipped slides
+// included.
+func (m model) slideAt(position int) *Slide {
+	slide := m.rootSlide
+	for i := 0; i < position && slide != nil; i++ {
+		slide = slide.Next
+	}
+	return slide
+}
+
+// next moves to the next slide not skipped, with its transition.
+func (m *model) next() tea.Cmd {
+	if m.slide.NextVisible() == nil || m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating() {
+		return nil
+	}
+	from := m.slide
+	m.navigateToSlide(m.slide.NextVisible())
+	return m.animate(from, m.slide.Properties.Transition, false)
+}
+
+// prev moves to the previous slide not skipped, playing the transition of the
+// slide being left backwards.
+func (m *model) prev() tea.Cmd {
+	if m.slide.PrevVisible() == nil || m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating() {
+		return nil
+	}
+	from := m.slide
+	m.navigateToSlide(m.slide.PrevVisible())
+	return m.animate(from, from.Properties.Transition.Opposite(), true)
+}
+
+// followLink navigates to the slide an internal link points to.
+func (m *model) followLink(link markdown.Link) tea.Cmd {
+	target := m.slide.Resolve(link.Target)
+	if target == nil {
+		slog.Warn("Link points to no slide", "target", link.Target)
+		return nil
+	}
+	return m.jumpTo(target)
+}
+
 // syncCurrentSlide broadcasts the current slide number to speaker notes clients
 func (m *model) syncCurrentSlide() {
 	if m.syncServer == nil {
@@ -110,6 +339,72 @@ func (m *model) syncCurrentSlide() {
 	m.syncServer.BroadcastSlideChange(slidePos)
 }
 
+// syncScreen broadcasts what the audience is shown to speaker notes clients
+func (m *model) syncScreen() {
+	if m.syncServer == nil {
+		return
+	}
+	m.syncServer.BroadcastScreenChange(m.screen())
+}
+
+// waitForGoTo waits for speaker notes clients to ask for a slide.
+func (m model) waitForGoTo() tea.Cmd {
+	server := m.syncServer
+	return func() tea.Msg {
+		return RemoteGoToMsg{SlideNumber: <-server.GoTo()}
+	}
+}
+
+// RemoteGoToMsg is sent when the speaker notes ask to move to a slide, by its
+// position in the deck.
+type RemoteGoToMsg struct {
+	SlideNumber int
+}
+
+// waitForImage waits for an image drawn in the background to be ready.
+func waitForImage() tea.Cmd {
+	return func() tea.Msg {
+		return ImageLoadedMsg{Path: <-img.Loaded()}
+	}
+}
+
+// ImageLoadedMsg is sent when an image drawn in the background is ready, for
+// the slides showing it to be redrawn.
+type ImageLoadedMsg struct {
+	Path string
+}
+
+// displayed returns the slide shown to the audience, which stays the same
+// while the screen is frozen.
+func (m model) displayed() *Slide {
+	if m.frozen != nil {
+		return m.frozen
+	}
+	return m.slide
+}
+
+func (m model) screen() Screen {
+	switch {
+	case m.blank != "":
+		return m.blank
+	case m.frozen != nil:
+		return ScreenFrozen
+	default:
+		return ScreenLive
+	}
+}
+
+// toggleBlank blanks the screen to blank, or shows it again if it already
+// is.
+func (m *model) toggleBlank(blank Screen) {
+	if m.blank == blank {
+		m.blank = ""
+	} else {
+		m.blank = blank
+	}
+	m.syncScreen()
+}
+
 type model struct {
 	width  int
 	height int
@@ -120,37 +415,71 @@ type model struct {
 	command          *Command
 	goTo             *GoTo
 	jump             *Jump
+	linkHints        *LinkHints
+	mark             *Mark
+	history          History
+	marks            map[rune]int
+	pointer          pointer
+	annotations      Annotations
+	blank            Screen
+	frozen           *Slide
+	renders          *renderMeter
 	rootSlide        *Slide
+	deck             Deck
 	globalTimer      Timer
 	timerDisplay     TimerDisplay
 	syncServer       *SyncServer
 	presentationFile string
 }
 
-func New(rootSlide *Slide, presentationFile string) model {
-	// Initialize timer only for the first slide
-	if rootSlide != nil {
-		rootSlide.Timer = NewTimer().Start()
+// New returns the model presenting the deck starting at rootSlide, with the
+// annotations drawn over its slides so far. Annotations are saved next to
+// presentationFile, or not at all when it is empty.
+func New(rootSlide *Slide, deck Deck, presentationFile string, annotations Annotations) model {
+	m := newModel(rootSlide, deck, presentationFile, annotations)
+	if m.slide != nil {
+		m.slide.prefetch()
 	}
+	m.renders = &renderMeter{}
 
 	// Create sync server for speaker notes communication
 	syncServer, err := NewSyncServer()
 	if err != nil {
 		slog.Error("Failed to create sync server", "error", err)
-		syncServer = nil
 	} else {
 		syncServer.Start()
 		slog.Info("Sync server ready for speaker notes")
+		m.syncServer = syncServer
+	}
+
+	return m
+}
+
+// newModel returns the model showing the deck starting at rootSlide, without
+// the sync server and the render measurements only a live presentation
+// needs.
+func newModel(rootSlide *Slide, deck Deck, presentationFile string, annotations Annotations) model {
+	attachDeck(rootSlide, &deck)
+
+	// Start at the first slide that is not skipped and initialize timer only
+	// for it
+	slide := rootSlide
+	if slide != nil {
+		slide = slide.FirstVisible()
+		slide.Timer = NewTimer().Start()
+		slide.enter(false)
 	}
 
 	return model{
-		slide:            rootSlide,
+		slide:            slide,
 		keys:             keys,
 		help:             help.New(),
 		rootSlide:        rootSlide,
+		deck:             deck,
 		globalTimer:      NewTimer().Start(),
 		timerDisplay:     NewTimerDisplay(),
-		syncServer:       syncServer,
+		annotations:      annotations,
+		marks:            map[rune]int{},
 		presentationFile: presentationFile,
 	}
 }
@@ -159,12 +488,28 @@ func (m model) Init() tea.Cmd {
 	// Initial sync for speaker notes
 	m.syncCurrentSlide()
 
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		tea.ClearScreen,
 		// tea.Tick(time.Second, func(time.Time) tea.Msg {
 		// 	return TimerTickMsg{}
 		// }),
-	)
+	}
+
+	if m.deck.Config.Title != "" {
+		cmds = append(cmds, tea.SetWindowTitle(m.deck.Config.Title))
+	}
+
+	if m.syncServer != nil {
+		cmds = append(cmds, m.waitForGoTo())
+	}
+
+	cmds = append(cmds, waitForImage())
+
+	if m.slide != nil && m.slide.entrance.Animating() {
+		cmds = append(cmds, transitions.Animate(transitions.Fps))
+	}
+
+	return tea.Batch(cmds...)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -172,16 +517,23 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		slog.Info("Key pressed", "key", keyMsg.String())
 	}
 
+	// Images drawn in the background show on the redraw following any
+	// message, whatever is open
+	if _, ok := msg.(ImageLoadedMsg); ok {
+		return m, waitForImage()
+	}
+
 	if m.command != nil && m.command.IsShowing() {
 		command, cmd := m.command.Update(msg)
 		m.command = &command
 
 		if command.quitting || command.Choice() != nil {
+			var cmd tea.Cmd
 			if command.Choice() != nil {
-				m.navigateToSlide(command.Choice())
+				cmd = m.jumpTo(command.Choice())
 			}
 			m.command = nil
-			return m, nil
+			return m, cmd
 		}
 		return m, cmd
 	}
@@ -191,18 +543,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.goTo = &goTo
 
 		if goTo.Quitting() {
+			var cmd tea.Cmd
 			if choice := goTo.Choice(); choice > 0 {
-				// Find the slide at the specified position
-				slide := m.rootSlide
+				// Find the slide at the specified position, skipped slides
+				// not counting
+				slide := m.rootSlide.FirstVisible()
 				for i := 1; i < choice && slide != nil; i++ {
-					slide = slide.Next
+					slide = slide.NextVisible()
 				}
 				if slide != nil {
-					m.navigateToSlide(slide)
+					cmd = m.jumpTo(slide)
 				}
 			}
 			m.goTo = nil
-			return m, nil
+			return m, cmd
 		}
 		return m, cmd
 	}
@@ -212,27 +566,79 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.jump = &jump
 
 		if jump.Quitting() {
+			var cmd tea.Cmd
 			if steps := jump.JumpSteps(); steps != 0 {
 				newSlide := m.slide
 				if steps > 0 {
 					// Jump forward
-					for i := 0; i < steps && newSlide.Next != nil; i++ {
-						newSlide = newSlide.Next
+					for i := 0; i < steps && newSlide.NextVisible() != nil; i++ {
+						newSlide = newSlide.NextVisible()
 					}
 				} else {
 					// Jump backward
-					for i := 0; i < -steps && newSlide.Prev != nil; i++ {
-						newSlide = newSlide.Prev
+					for i := 0; i < -steps && newSlide.PrevVisible() != nil; i++ {
+						newSlide = newSlide.PrevVisible()
 					}
 				}
-				m.navigateToSlide(newSlide)
+				cmd = m.jumpTo(newSlide)
 			}
 			m.jump = nil
-			return m, nil
+			return m, cmd
+		}
+		return m, cmd
+	}
+
+	if m.linkHints != nil && m.linkHints.IsShowing() {
+		linkHints, cmd := m.linkHints.Update(msg)
+		m.linkHints = &linkHints
+
+		if linkHints.Quitting() {
+			var cmd tea.Cmd
+			if link, ok := linkHints.Choice(); ok {
+				cmd = m.followLink(link)
+			}
+			m.linkHints = nil
+			return m, cmd
 		}
 		return m, cmd
 	}
 
+	if m.mark != nil && m.mark.IsShowing() {
+		mark, cmd := m.mark.Update(msg)
+		m.mark = &mark
+
+		if mark.Quitting() {
+			var cmd tea.Cmd
+			if letter := mark.Letter(); letter != 0 {
+				if mark.Setting() {
+					if m.marks == nil {
+						m.marks = map[rune]int{}
+					}
+					m.marks[letter] = m.slide.position()
+				} else if position, ok := m.marks[letter]; ok {
+					cmd = m.jumpTo(m.slideAt(position))
+				}
+			}
+			m.mark = nil
+			return m, cmd
+		}
+		return m, cmd
+	}
+
+	if m.annotations.IsActive() {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok &&
+			(key.Matches(keyMsg, m.keys.Annotate) || keyMsg.String() == "esc") {
+			m.annotations = m.annotations.Toggle()
+			return m, nil
+		}
+
+		annotations, handled := m.annotations.Update(msg, m.displayed().position())
+		m.annotations = annotations
+		if handled {
+			return m, nil
+		}
+	}
+
 	switch msg := msg.(type) {
 	case UpdateSlidesMsg:
 		// Find current position in the slide list
@@ -241,11 +647,27 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			currentPosition++
 		}
 
-		// Update root and navigate to the same position in the new list
+		// Update root and navigate to the same position in the new list, or
+		// the closest slide still shown when it is now skipped
 		m.slide = msg.NewRoot
 		m.rootSlide = msg.NewRoot
-		for i := 0; i < currentPosition && m.slide != nil; i++ {
-			m.slide = m.slide.Next
+		m.deck = msg.Deck
+		attachDeck(msg.NewRoot, &msg.Deck)
+		if m.slide != nil {
+			for i := 0; i < currentPosition && m.slide.Next != nil; i++ {
+				m.slide = m.slide.Next
+			}
+			m.slide = m.slide.ClosestVisible()
+		}
+
+		// Keep the frozen screen on the same position too
+		if m.frozen != nil && msg.NewRoot != nil {
+			frozenPosition := m.frozen.position()
+			m.frozen = msg.NewRoot
+			for i := 0; i < frozenPosition && m.frozen.Next != nil; i++ {
+				m.frozen = m.frozen.Next
+			}
+			m.frozen = m.frozen.ClosestVisible()
 		}
 
 		// Reset state for all slides in the new list
@@ -253,6 +675,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			currentSlide.ActiveTransition = nil
 			currentSlide.Style = style(m.width, m.height, currentSlide.Properties.Style)
 		}
+		if m.slide != nil {
+			m.slide.prefetch()
+		}
+		m.syncCurrentSlide()
+		return m, nil
+	case AssetsChangedMsg:
+		for slide := m.rootSlide; slide != nil; slide = slide.Next {
+			if slide.Evict(msg.Paths) {
+				slog.Info("Evicted image cache", "slide", slide.Properties.Title)
+			}
+		}
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.width, m.height = msg.Width, msg.Height
@@ -262,6 +695,41 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			slide = slide.Next
 		}
 		return m, nil
+	case RemoteGoToMsg:
+		target := m.slideAt(msg.SlideNumber)
+
+		var cmd tea.Cmd
+		switch {
+		case target == nil || target == m.slide:
+		case target == m.slide.NextVisible():
+			cmd = m.next()
+		case target == m.slide.PrevVisible():
+			cmd = m.prev()
+		default:
+			cmd = m.jumpTo(target)
+		}
+		return m, tea.Batch(cmd, m.waitForGoTo())
+	case tea.MouseMsg:
+		m.pointer.x, m.pointer.y = msg.X, msg.Y
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+
+		switch msg.Button {
+		case tea.MouseButtonLeft:
+			if m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating() {
+				return m, nil
+			}
+			if link, ok := linkAt(m.slideView(), m.displayed().links, msg.X, msg.Y); ok {
+				return m, m.followLink(link)
+			}
+			return m, m.next()
+		case tea.MouseButtonWheelDown:
+			return m, m.next()
+		case tea.MouseButtonRight, tea.MouseButtonWheelUp:
+			return m, m.prev()
+		}
+		return m, nil
 	case tea.KeyMsg:
 
 		if key.Matches(msg, m.keys.Quit) {
@@ -276,11 +744,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.command = &command
 			return m, nil
 		} else if key.Matches(msg, m.keys.GoTo) {
-			count := 0
-			for slide := m.rootSlide; slide != nil; slide = slide.Next {
-				count++
-			}
-			goTo := NewGoTo(count)
+			goTo := NewGoTo(m.rootSlide.Total())
 			goTo = goTo.SetShowing(true)
 			m.goTo = &goTo
 			return m, nil
@@ -291,35 +755,82 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			jump, cmd := jump.Update(msg)
 			m.jump = &jump
 			return m, cmd
+		} else if key.Matches(msg, m.keys.SetMark) || key.Matches(msg, m.keys.GoToMark) {
+			mark := NewMark(key.Matches(msg, m.keys.SetMark))
+			mark = mark.SetShowing(true)
+			m.mark = &mark
+			return m, nil
+		} else if key.Matches(msg, m.keys.Back) {
+			history, position, ok := m.history.Back(m.slide.position())
+			if !ok {
+				return m, nil
+			}
+			m.history = history
+			return m, m.moveTo(m.slideAt(position))
+		} else if key.Matches(msg, m.keys.Forward) {
+			history, position, ok := m.history.Forward()
+			if !ok {
+				return m, nil
+			}
+			m.history = history
+			return m, m.moveTo(m.slideAt(position))
+		} else if key.Matches(msg, m.keys.Links) {
+			if m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating() {
+				return m, nil
+			}
+			linkHints := NewLinkHints(m.slideView(), m.displayed().links)
+			m.linkHints = &linkHints
+			return m, nil
 		} else if key.Matches(msg, m.keys.Timer) {
 			m.timerDisplay = m.timerDisplay.ToggleVisible()
 			return m, nil
-		} else if key.Matches(msg, m.keys.Next) {
-			if m.slide.Next == nil || m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating() {
-				return m, nil
+		} else if key.Matches(msg, m.keys.Pointer) {
+			m.pointer.visible = !m.pointer.visible
+			return m, nil
+		} else if key.Matches(msg, m.keys.Blackout) {
+			m.toggleBlank(ScreenBlack)
+			return m, nil
+		} else if key.Matches(msg, m.keys.Whiteout) {
+			m.toggleBlank(ScreenWhite)
+			return m, nil
+		} else if key.Matches(msg, m.keys.Freeze) {
+			if m.frozen != nil {
+				m.frozen = nil
+			} else {
+				if m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating() {
+					return m, nil
+				}
+				m.frozen = m.slide
 			}
-			m.navigateToSlide(m.slide.Next)
-			m.slide.ActiveTransition = m.slide.Properties.Transition.Start(m.width, m.height, transitions.Forwards)
-			return m, transitions.Animate(transitions.Fps)
-		} else if key.Matches(msg, m.keys.Prev) {
-			if m.slide.Prev == nil || m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating() {
+			m.syncScreen()
+			return m, nil
+		} else if key.Matches(msg, m.keys.Annotate) {
+			m.annotations = m.annotations.Toggle()
+			return m, nil
+		} else if key.Matches(msg, m.keys.Save) {
+			if m.presentationFile == "" {
+				slog.Info("Annotations are not saved for this presentation")
 				return m, nil
 			}
-			m.navigateToSlide(m.slide.Prev)
-			m.slide.ActiveTransition = m.slide.
-				Next.
-				Properties.
-				Transition.
-				Opposite().
-				Start(m.width, m.height, transitions.Backwards)
-
-			return m, transitions.Animate(transitions.Fps)
-		} else if key.Matches(msg, m.keys.Top) {
-			m.navigateToSlide(m.slide.First())
+			path := AnnotationsFile(m.presentationFile)
+			if err := m.annotations.Save(path); err != nil {
+				slog.Error("Failed to save annotations", "error", err)
+			} else {
+				slog.Info("Saved annotations", "path", path)
+			}
 			return m, nil
+		} else if key.Matches(msg, m.keys.Next) {
+			return m, m.next()
+		} else if key.Matches(msg, m.keys.Prev) {
+			return m, m.prev()
+		} else if key.Matches(msg, m.keys.Top) {
+			return m, m.jumpTo(m.slide.FirstVisible())
 		} else if key.Matches(msg, m.keys.Bottom) {
-			m.navigateToSlide(m.slide.Last())
-			return m, nil
+			return m, m.jumpTo(m.slide.LastVisible())
+		} else if key.Matches(msg, m.keys.NextSection) {
+			return m, m.jumpTo(m.slide.NextSection())
+		} else if key.Matches(msg, m.keys.PrevSection) {
+			return m, m.jumpTo(m.slide.PrevSection())
 		}
 	case transitions.FrameMsg:
 		slide, cmd := m.slide.Update()
@@ -334,29 +845,58 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m model) View() string {
-	m.slide.Style = style(m.width, m.height, m.slide.Properties.Style)
+// slideView renders the slide shown to the audience, or the transition to
+// it, centered in the terminal.
+func (m model) slideView() string {
+	slide := m.displayed()
+	slide.Style = style(m.width, m.height, slide.Properties.Style)
 
 	hasOverlay := (m.command != nil && m.command.IsShowing()) ||
 		(m.goTo != nil && m.goTo.IsShowing()) ||
-		(m.jump != nil && m.jump.IsShowing())
+		(m.jump != nil && m.jump.IsShowing()) ||
+		(m.mark != nil && m.mark.IsShowing()) ||
+		(m.linkHints != nil && m.linkHints.IsShowing())
 
-	slideView := lipgloss.Place(
+	return lipgloss.Place(
 		m.width,
 		m.height,
 		lipgloss.Center,
 		lipgloss.Center,
-		m.slide.View(
-			(m.slide.ActiveTransition != nil && m.slide.ActiveTransition.Animating()) || hasOverlay,
+		slide.View(
+			(slide.ActiveTransition != nil && slide.ActiveTransition.Animating()) || hasOverlay,
+			m.globalTimer.Duration(),
 		),
 	)
+}
+
+func (m model) View() string {
+	start := time.Now()
+	view := m.view()
+	m.renders.record(time.Since(start))
+	// The kitty images placed by earlier views and no longer shown, like
+	// those under an overlay or of the slide left, are removed before the
+	// view is drawn
+	return img.ClearHiddenImages(view) + view
+}
+
+func (m model) view() string {
+	if m.blank != "" {
+		return blankView(m.blank, m.width, m.height, m.slide.Style.Theme)
+	}
+
+	slideView := m.slideView()
 
 	lines := strings.Split(slideView, "\n")
 	if len(lines) > m.height {
-		fmt.Print("\x1b_Ga=d\x1b\\")
 		return m.exceedScreenSizeView()
 	}
 
+	// Annotations are drawn over the still slide only, since they would not
+	// line up with its content during a transition
+	if slide := m.displayed(); slide.ActiveTransition == nil || !slide.ActiveTransition.Animating() {
+		slideView = m.annotations.Show(slideView, slide.position())
+	}
+
 	if m.command != nil && m.command.IsShowing() {
 		return m.command.Show(slideView, m.width, m.height)
 	}
@@ -369,11 +909,19 @@ func (m model) View() string {
 		return m.jump.Show(slideView, m.width, m.height)
 	}
 
+	if m.mark != nil && m.mark.IsShowing() {
+		return m.mark.Show(slideView, m.width, m.height)
+	}
+
+	if m.linkHints != nil && m.linkHints.IsShowing() {
+		return m.linkHints.Show(slideView)
+	}
+
 	if m.timerDisplay.IsVisible() {
-		return m.timerDisplay.Show(slideView, m.width, m.height, m.globalTimer, m.slide.Timer)
+		slideView = m.timerDisplay.Show(slideView, m.width, m.height, m.globalTimer, m.slide.Timer, m.deck.Config.Duration)
 	}
 
-	return slideView
+	return m.pointer.Show(slideView)
 }
 
 // GetSyncServer returns the sync server instance